@@ -0,0 +1,307 @@
+//==============================================================================
+// consensus: Constraint CONSENSUS heuristic
+// 01   Oct. 18, 2026   Initial version
+
+
+// This file contains the constraint consensus method of Chinneck, which moves a
+// point towards the feasible region of the model in the Rows, Cols, and Elems
+// global variables. It is intended as a crash start for heuristics, and finds a
+// point which is near feasible, not necessarily feasible.
+//
+// The feasibility vector of a violated row is the shortest move which satisfies the
+// row if it were linear in the point, i.e. the violation times the gradient of the
+// row divided by the square of the gradient length. Rows whose feasibility vector
+// is shorter than the feasibility tolerance are taken as satisfied. The feasibility
+// vectors of all violated rows are combined into a consensus vector, the point is
+// moved along it and then back within the column bounds, and the process is
+// repeated until no row is violated, the move becomes too short, or the maximum
+// number of iterations is reached. Integer restrictions are ignored.
+
+package lpo
+
+import (
+	"github.com/pkg/errors"
+	"math"
+)
+
+
+// Methods of combining feasibility vectors used by ConstraintConsensus
+const (
+	CcBasic = "BASIC"  // Average of the components of each column
+	CcDBmax = "DBMAX"  // Largest component in the direction with the most votes
+	CcSum   = "SUM"    // Sum of the components of each column
+)
+
+// Reasons why ConstraintConsensus stopped
+const (
+	CcStopFeasible = "FEASIBLE"  // No row is violated
+	CcStopMove     = "MOVE"      // Consensus vector shorter than the move tolerance
+	CcStopMaxIter  = "MAX_ITER"  // Maximum number of iterations reached
+)
+
+// Default values used by ConstraintConsensus for fields of CcCtrl set to zero
+const (
+	ccDefMaxIter = 500   // Maximum number of iterations
+	ccDefMoveTol = 1e-4  // Length of consensus vector below which iterations stop
+)
+
+// CcCtrl contains the parameters of ConstraintConsensus. Fields set to zero are
+// given default values: CcBasic for the method, 500 iterations, Featol for the
+// feasibility tolerance, and 1e-4 for the move tolerance.
+type CcCtrl struct {
+	Method   string   // CcBasic, CcDBmax, or CcSum
+	MaxIter  int      // Maximum number of iterations
+	FeasTol  float64  // Length of feasibility vector below which a row is satisfied
+	MoveTol  float64  // Length of consensus vector below which iterations stop
+}
+
+// CcIterStats contains the statistics of a single iteration of ConstraintConsensus,
+// measured at the point at the start of the iteration.
+type CcIterStats struct {
+	Iter     int      // Iteration number
+	NumViol  int      // Number of rows violated
+	SumViol  float64  // Sum of the lengths of the feasibility vectors
+	MaxViol  float64  // Length of the longest feasibility vector
+	MoveLen  float64  // Length of the consensus vector
+}
+
+// CcResult contains the results of ConstraintConsensus. The point is listed in the
+// same order as Cols. NumViol and SumViol are those of the point returned.
+type CcResult struct {
+	Point      []float64      // Point found
+	Iter       int            // Number of iterations performed
+	StopReason string         // CcStopFeasible, CcStopMove, or CcStopMaxIter
+	NumViol    int            // Number of rows violated at the point found
+	SumViol    float64        // Sum of the lengths of the feasibility vectors at the point
+	Stats      []CcIterStats  // Statistics of each iteration
+}
+
+//==============================================================================
+
+// ccFeasVectors finds the rows violated at the point passed to the function
+// (point), and combines their feasibility vectors with the method specified
+// (method) into the consensus vector (move). The LHS of the rows (lhs) and the
+// lists of votes and components of each column (numVotes, sumComp, maxComp,
+// minComp, numPos, numNeg) are passed in to avoid allocating memory. The
+// statistics of the point are returned in stats.
+func ccFeasVectors(method string, feasTol float64, point []float64, move []float64,
+	lhs []float64, numVotes []int, numPos []int, numNeg []int, sumComp []float64,
+	maxComp []float64, minComp []float64, stats *CcIterStats) {
+	var viol  float64  // violation of row being processed
+	var dist  float64  // length of feasibility vector of row
+	var comp  float64  // component of feasibility vector
+	var iel   int      // index of item in elements list
+	var icol  int      // index of column
+
+	stats.NumViol = 0
+	stats.SumViol = 0
+	stats.MaxViol = 0
+
+	for i := 0; i < len(Rows); i++ {
+		lhs[i] = 0
+	}
+
+	for i := 0; i < len(Elems); i++ {
+		lhs[Elems[i].InRow] += Elems[i].Value * point[Elems[i].InCol]
+	}
+
+	for j := 0; j < len(Cols); j++ {
+		numVotes[j] = 0
+		numPos[j]   = 0
+		numNeg[j]   = 0
+		sumComp[j]  = 0
+		maxComp[j]  = 0
+		minComp[j]  = 0
+	}
+
+	// Add the feasibility vector of each violated row to the columns of the row.
+	for i := 0; i < len(Rows); i++ {
+		if i == ObjRow || Rows[i].Type == "N" || Rows[i].GradVecLenSq <= 0 {
+			continue
+		}
+
+		viol = 0
+		if lhs[i] < Rows[i].RHSlo {
+			viol = Rows[i].RHSlo - lhs[i]
+		} else if lhs[i] > Rows[i].RHSup {
+			viol = Rows[i].RHSup - lhs[i]
+		}
+
+		dist = math.Abs(viol) / Rows[i].GradVecLen
+		if dist <= feasTol {
+			continue
+		}
+
+		stats.NumViol++
+		stats.SumViol += dist
+		stats.MaxViol  = math.Max(stats.MaxViol, dist)
+
+		for k := 0; k < len(Rows[i].HasElems); k++ {
+			iel  = Rows[i].HasElems[k]
+			icol = Elems[iel].InCol
+			comp = viol * Elems[iel].Value / Rows[i].GradVecLenSq
+
+			numVotes[icol]++
+			sumComp[icol] += comp
+
+			if comp > 0 {
+				numPos[icol]++
+				maxComp[icol] = math.Max(maxComp[icol], comp)
+			} else if comp < 0 {
+				numNeg[icol]++
+				minComp[icol] = math.Min(minComp[icol], comp)
+			}
+		}
+	} // End for all rows
+
+	// Combine the components of each column into the consensus vector.
+	for j := 0; j < len(Cols); j++ {
+		move[j] = 0
+		if numVotes[j] == 0 {
+			continue
+		}
+
+		switch method {
+		case CcSum:
+			move[j] = sumComp[j]
+
+		case CcDBmax:
+			switch {
+			case numPos[j] > numNeg[j]:
+				move[j] = maxComp[j]
+			case numNeg[j] > numPos[j]:
+				move[j] = minComp[j]
+			default:
+				move[j] = (maxComp[j] + minComp[j]) / 2
+			}
+
+		default:
+			move[j] = sumComp[j] / float64(numVotes[j])
+		}
+	} // End for all columns
+}
+
+//==============================================================================
+
+// ConstraintConsensus moves the point passed to the function (point), which holds
+// the value of every column in the same order as Cols, towards the feasible region
+// of the model with the constraint consensus method, using the parameters in
+// ccCtrl. The point is first moved within the column bounds, and is kept within
+// them after every move. The point passed in is not modified. The point found,
+// the reason for stopping, and the statistics of each iteration are returned in
+// ccRslt.
+// In case of failure, function returns an error.
+func ConstraintConsensus(ccCtrl CcCtrl, point []float64, ccRslt *CcResult) error {
+	var move     []float64  // consensus vector
+	var lhs      []float64  // LHS of each row
+	var sumComp  []float64  // sum of components of feasibility vectors of each column
+	var maxComp  []float64  // largest component of each column
+	var minComp  []float64  // smallest (most negative) component of each column
+	var numVotes []int      // number of feasibility vectors including each column
+	var numPos   []int      // number of positive components of each column
+	var numNeg   []int      // number of negative components of each column
+	var stats CcIterStats   // statistics of the current iteration
+	var moveLen  float64    // length of consensus vector
+
+	if ccRslt == nil {
+		return errors.New("ConstraintConsensus received nil results structure")
+	}
+
+	if len(point) != len(Cols) {
+		return errors.Errorf("ConstraintConsensus received %d values, expected %d",
+			len(point), len(Cols))
+	}
+
+	if ccCtrl.Method == "" {
+		ccCtrl.Method = CcBasic
+	}
+
+	if ccCtrl.Method != CcBasic && ccCtrl.Method != CcDBmax && ccCtrl.Method != CcSum {
+		return errors.Errorf("ConstraintConsensus received unknown method %s", ccCtrl.Method)
+	}
+
+	if ccCtrl.MaxIter <= 0 {
+		ccCtrl.MaxIter = ccDefMaxIter
+	}
+
+	if ccCtrl.FeasTol <= 0 {
+		ccCtrl.FeasTol = Featol
+	}
+
+	if ccCtrl.MoveTol <= 0 {
+		ccCtrl.MoveTol = ccDefMoveTol
+	}
+
+	*ccRslt = CcResult{}
+	ccRslt.Point = make([]float64, len(Cols))
+
+	move     = make([]float64, len(Cols))
+	lhs      = make([]float64, len(Rows))
+	sumComp  = make([]float64, len(Cols))
+	maxComp  = make([]float64, len(Cols))
+	minComp  = make([]float64, len(Cols))
+	numVotes = make([]int, len(Cols))
+	numPos   = make([]int, len(Cols))
+	numNeg   = make([]int, len(Cols))
+
+	_ = calcGradVec()
+
+	// Start from the point moved within the column bounds.
+	for j := 0; j < len(Cols); j++ {
+		ccRslt.Point[j] = math.Max(Cols[j].BndLo, math.Min(Cols[j].BndUp, point[j]))
+	}
+
+	log(pINFO, "\nConstraint consensus (%s) started.\n", ccCtrl.Method)
+
+	ccRslt.StopReason = CcStopMaxIter
+
+	for iter := 1; iter <= ccCtrl.MaxIter + 1; iter++ {
+
+		ccFeasVectors(ccCtrl.Method, ccCtrl.FeasTol, ccRslt.Point, move, lhs, numVotes,
+			numPos, numNeg, sumComp, maxComp, minComp, &stats)
+
+		ccRslt.NumViol = stats.NumViol
+		ccRslt.SumViol = stats.SumViol
+
+		if stats.NumViol == 0 {
+			ccRslt.StopReason = CcStopFeasible
+			break
+		}
+
+		// The last evaluation only measures the point found by the last iteration.
+		if iter > ccCtrl.MaxIter {
+			break
+		}
+
+		moveLen = 0
+		for j := 0; j < len(Cols); j++ {
+			moveLen += move[j] * move[j]
+		}
+		moveLen = math.Sqrt(moveLen)
+
+		stats.Iter    = iter
+		stats.MoveLen = moveLen
+
+		if moveLen < ccCtrl.MoveTol {
+			ccRslt.StopReason = CcStopMove
+			break
+		}
+
+		ccRslt.Iter  = iter
+		ccRslt.Stats = append(ccRslt.Stats, stats)
+		log(pDEB, "  Iteration %d: %d rows violated, sum %e, move %e.\n", iter,
+			stats.NumViol, stats.SumViol, moveLen)
+
+		for j := 0; j < len(Cols); j++ {
+			ccRslt.Point[j] = math.Max(Cols[j].BndLo, math.Min(Cols[j].BndUp,
+				ccRslt.Point[j] + move[j]))
+		}
+	} // End for all iterations
+
+	log(pINFO, "Constraint consensus stopped (%s) after %d iterations, %d rows violated.\n",
+		ccRslt.StopReason, ccRslt.Iter, ccRslt.NumViol)
+
+	return nil
+}
+
+//============================ END OF FILE =====================================
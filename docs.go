@@ -39,7 +39,14 @@ The presolving algorithms supported by lpo at this time include:
 	- removing empty rows              (constraint has no variables)
 	- removing row singletons          (constraints that have a single variable)
 	- removing fixed variables         (upper bound equals the lower bound)
-	- removing free column singletons  (unbounded variable present only in the objective function)
+	- removing free column singletons  (free or implied free variable present in a single constraint)
+	- removing duplicate rows          (constraints whose coefficients are multiples of another one)
+	- merging duplicate columns        (variables whose coefficients are multiples of another one)
+	- removing forcing rows            (constraints that can only be met with all variables at a bound)
+	- removing redundant rows          (constraints satisfied for all values of their variables)
+	- tightening coefficients          (MILP constraints whose integer coefficients exceed the RHS)
+	- probing binary variables         (fixings, bounds and implications from setting a binary to 0 or 1)
+	- extending and merging cliques    (multiple choice constraints implied by larger ones)
 
 You can control which of these presolving methods are invoked
 by setting the appropriate boolean flags and specifying the number of iterations
@@ -55,11 +62,73 @@ to be performed. The configurable parameters are:
         DelRowSingleton  bool    // Controls if row singletons are removed
         DelColSingleton  bool    // Controls if column singletons are removed
         DelFixedVars     bool    // Controls if fixed variables are removed
+        DelDupRows       bool    // Controls if duplicate (parallel) rows are removed
+        DelDupCols       bool    // Controls if duplicate (parallel) columns are merged
+        DelForcingRows   bool    // Controls if forcing and redundant rows are removed
+        TightenCoefs     bool    // Controls if coefficients of MILP rows are tightened
+        Probe            bool    // Controls if binary variables of MILP models are probed
+        ProbeMaxCols     int     // Maximum number of columns probed, or 0 for no limit
+        ProbeTimeLimit   float64 // Maximum total time in seconds spent probing, or 0 for no limit
+        MergeCliques     bool    // Controls if clique rows of MILP models are extended and merged
+        ScaleMethod      string  // Scaling of reduced model (ScaleEquil, ScaleGeom, ScaleArith, ScaleCurtisReid), or "" for none
+        VerifySoln       bool    // Controls if the solution is verified against the original model
+        Ranging          bool    // Controls if RHS and objective ranging of LP models is calculated
+        SimplexDiag      bool    // Controls if SimplexSolve diagnoses a reduced model the solver failed on
         RunSolver        bool    // Controls if problem is to be solved 		
     }
 
+Binary variables are probed in the first iteration only, since probing visits
+every binary column of the model.
+
 Additional reductions will be included in future enhancements.
 
+If presolve finds the model to be infeasible, the cause of the error returned by
+ReduceMatrix (see errors.Cause) is a *PsInfeasError. It names the row or column at
+which the infeasibility was found, and lists the chain of bounds derived from rows
+of the model, in the order they were derived, which proves that the model has no
+feasible solution. Bounds derived by probing, and columns fixed at 0 by merging
+clique rows, are listed without a row.
+
+Presolve also looks for columns whose cost improves the objective function without
+limit, because they have no finite bound in that direction and none of their rows
+limit them. Such a model is unbounded if it is feasible: the Unbounded flag of
+PsSoln is set, and its Ray field holds the primal ray completed to the original
+variables by PostSolveRay. If the solver reports the reduced model unbounded or
+infeasible, CplexSolveProb and CoinSolveProb return an error whose cause is a
+*PsUnbndError, which includes the ray of the original model when one can be found,
+or a *PsInfeasError. The status is read from the solution file written by Cplex,
+and from the status of the Coin-OR solution. The ray is that found by presolve or,
+failing that, the primal ray returned by Coin-OR and completed by PostSolveRay.
+If the SimplexDiag flag of PsCtrl is set and the reduced model is small,
+SimplexSolve is used to find the ray when neither provides one (the gpx package
+gives no access to the ray found by Cplex), and to find out whether the model is
+unbounded or infeasible when Cplex returns neither a solution nor its status.
+
+ReduceMatrixReport performs the same reductions as ReduceMatrix, and also returns
+a PsReport listing, for every pass in every iteration, the number of rows, columns
+and elements removed, the number of bounds tightened and rows changed, and the
+time spent, along with the totals for each pass and the reason presolve stopped.
+The report is also stored in the Report field of PsSoln by CplexSolveProb and
+CoinSolveProb, and can be printed with PrintPsReport.
+
+Scaling
+
+ScaleModel scales the rows and columns of the model by powers of 2, so that the
+coefficients are closer to 1, using equilibration (ScaleEquil), the iterated
+geometric mean (ScaleGeom) or the iterated arithmetic mean (ScaleArith) of their
+absolute values. For badly scaled models, Curtis-Reid scaling (ScaleCurtisReid)
+finds the factors which minimize the sum of the squares of the logarithms of the
+scaled coefficients, by solving the least squares problem with the conjugate
+gradient method. The range of the coefficients before and after scaling is
+returned in a ScaleReport, which can be printed with PrintScaleReport, and is
+stored in the Scaling field of PsSoln by the solve functions. If the ScaleMethod
+field of PsCtrl is set, CplexSolveProb and CoinSolveProb scale the reduced model
+before it is sent to the solver, and the values, reduced costs, slacks and duals
+of the solution are unscaled before postsolve, so that PsSoln always refers to the
+original model. PostSolve unscales the solution in the same way if the model was
+scaled in the same process, or if the PSOP file written after scaling was read by
+ReadPsopFile, since the scale factors are saved in it.
+
 Creating Model Files
 
 Models can be created in 4 ways:
@@ -122,6 +191,38 @@ Users who do not wish to reduce and solve the problem as described above have th
 option of calling individual functions to only perform a specific task. Those
 functions are listed and described in the following sections.
 
+Verifying Solutions
+
+VerifySolution checks a solution returned in a PsSoln against the original model,
+as it was before ReduceMatrix was called. Every row and column bound is checked,
+as well as the integer restrictions and the objective function value, within
+Featol. The numbers and largest violations of each kind, and the names of the worst
+offenders, are returned in a VerifyReport, which can be printed with
+PrintVerifyReport. If the VerifySoln flag of PsCtrl is set, CplexSolveProb and
+CoinSolveProb verify the solution and attach the report to the Verify field of
+PsSoln. The original model is not stored in the PSOP file, so solutions cannot be
+verified after the presolve operations are read by ReadPsopFile.
+
+Sensitivity Analysis
+
+If the Ranging flag of PsCtrl is set, CplexSolveProb and CoinSolveProb return in
+the RhsRange and ObjRange maps of PsSoln, keyed by name, the interval of the RHS of
+each row and of the objective coefficient of each column of an LP model within
+which the optimal basis does not change. The range of a row applies to its active
+bound, given in the Bound field. The gpx package does not provide sensitivity
+analysis, so CplexSolveProb obtains the ranges of the reduced model from Cplex
+through the file interface (CplexRangeMps), while Coin-OR does not report them and
+CoinSolveProb calculates them with the in-process simplex solver, for models small
+enough to be solved by it. RangeModel calculates the ranges of the model as loaded
+with the in-process solver.
+
+The ranges of the reduced model are mapped to the original model by PostSolveRange,
+which can also be used after PostSolve. Rows and columns removed by presolve or
+merged with a duplicate, and all items of MILP models, are marked with RangeNA.
+Ranges are those of the basis of the reduced model, so they can differ from those
+of the original model when a column bound tightened by presolve is active. The
+ranges can be printed with PrintRanging.
+
 Interacting with Coin-OR
 
 Interaction with Coin-OR requires that the OSSolverService, delivered as one of the Coin-OR
@@ -181,6 +282,59 @@ Algorithmic researchers can write their own experimental solvers and simply use
 the lpo functions to read or create a model, to presolve it, to evaluate constraints 
 at a point, etc. 
 
+The solution of the reduced model can be completed to a solution of the original
+model by PostSolve, which accepts the constraints and variables of the reduced 
+solution keyed by name and returns a PsSoln. If the reduced model is solved by
+another process, the presolve operations are saved with WritePsopFile (with
+coefficients included) and restored with ReadPsopFile before calling PostSolve.
+The PSOP file starts with a version line and stores all values with full precision.
+
+Solutions written by external solvers can be read with ReadSolnFile, which supports
+MIPLIB .sol files of variable names and values, solution files written by CBC or
+CLP, and CSV files of variable name, value and reduced cost. ImportSoln reads such
+a file for the reduced model and returns the PsSoln of the original model.
+
+Small models can also be solved without an external solver by SimplexSolve, an
+in-process dense simplex solver which returns the values, duals, reduced costs,
+basis status, and RHS and objective ranges of the LP relaxation in an SpxSoln.
+
+A complete point, with a value for every column listed in the same order as Cols
+or keyed by name, is evaluated against the model by EvaluatePoint or
+EvaluatePointMap. They return the LHS, violation and status of every row, the
+violations of the bounds and integer restrictions, the objective function value,
+and the number, maximum and sum of the infeasibilities in a PointEval, which can
+be reused from call to call so that heuristics can evaluate many points quickly.
+
+ConstraintConsensus moves a point towards the feasible region with the constraint
+consensus method of Chinneck, and can be used as a crash start for heuristics. The
+feasibility vectors of the violated rows, calculated from their gradients, are
+combined by averaging (CcBasic), by taking the largest component in the direction
+with the most votes (CcDBmax), or by adding them (CcSum), and the point is kept
+within the column bounds. The point found and the statistics of each iteration are
+returned in a CcResult.
+
+FeasibilityPump searches for an integer feasible point of a MILP model, as read or
+as reduced by ReduceMatrix, with the feasibility pump. It alternates between
+rounding the integer columns of an LP solution and solving an LP which finds the
+point of the LP relaxation closest to the rounded point, and perturbs the rounded
+point when it cycles. The LPs are solved by a function passed in FpCtrl, such as
+SimplexSolve, CoinLpSolve or CplexLpSolve, together with the iteration and time
+limits. If no function is passed, SimplexSolve is used for models small enough
+for its dense tableau. The first integer feasible point found is returned in an
+FpResult, both for the model as loaded and, through postsolve, for the original
+model.
+
+Infeasible Models
+
+If a model is infeasible, FindIIS finds an irreducible infeasible subsystem (IIS)
+of its LP relaxation: a set of rows and column bounds which is infeasible, but which
+becomes feasible if any one of them is removed. The candidate set is first reduced
+by presolve, then by a deletion (IisDeletion) or additive (IisAdditive) filter
+which solves a sequence of submodels. The solver is passed as a function which only
+reports whether the model is feasible; SimplexFeasible, CoinFeasible and
+CplexFeasible are provided. The IIS is returned by name in an IisSoln, and can be
+printed with PrintIIS or written as an MPS file with WriteIisMpsFile.
+
 Additional Values Calculated by Solvers
 
 The ReducedCost value associated with variables and the Pi, Slack, and Dual values
@@ -194,9 +348,12 @@ as follows:
   Slack       - model is LP or MILP and constraint was processed by Cplex
   Dual        - model is LP and constraint was processed by Coin-OR
 
-Variables and constraints that were removed during preprocessing will not have
-these values, regardless of which model and solver was used. Future enhancements
-are planned to address this deficiency.
+For LP models, the values of variables and constraints that were removed during 
+preprocessing are reconstructed by the postsolve step from the values returned 
+by the solver. Removed constraints are given a dual value (stored as both Pi and 
+Dual) and a slack, and removed variables a reduced cost, such that the complete 
+solution satisfies dual feasibility for the original model. These values are not
+meaningful for MILP models.
 
 Tutorial and Function Exerciser
 
@@ -0,0 +1,473 @@
+//==============================================================================
+// eval: EVALuation of points against the model
+// 01   Oct. 18, 2026   Initial version
+
+
+// This file contains functions which evaluate a complete point, i.e. a value for
+// every column of the model, against all rows, bounds and integer restrictions of
+// the model in the Rows, Cols, and Elems global variables. Unlike CalcLhs and
+// CalcConViolation, which evaluate a single row at the values of its own columns,
+// the point is given over all columns, and all rows are evaluated in a single pass
+// over the elements. The structure holding the results can be reused from call to
+// call, so that heuristics can evaluate many points without allocating memory.
+//
+// It also contains the functions which verify a solution returned by the solve
+// functions against the original model, as it was before presolve.
+
+package lpo
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"math"
+	"sort"
+)
+
+
+// Status of a row returned by EvaluatePoint, with the same values as the status
+// returned by CalcConViolation
+const (
+	EvalSatisfied = 0  // Row is satisfied, or is non-binding
+	EvalViolated  = 1  // Row is violated by more than Featol
+	EvalTight     = 2  // Row is satisfied with a bound active within Featol
+)
+
+// Kinds of items listed in a VerifyReport
+const (
+	VerifyRow = "ROW"  // Row activity outside of the row bounds
+	VerifyBnd = "BND"  // Column value outside of the column bounds
+	VerifyInt = "INT"  // Integer column with a fractional value
+	VerifyObj = "OBJ"  // Objective function value differing from the one reported
+)
+
+// Constants used by VerifySolution
+const (
+	verifyMaxWorst = 10  // Maximum number of worst offenders listed
+)
+
+// PointEval contains the results of evaluating a point with EvaluatePoint. The
+// values of the rows are listed in the same order as Rows, and those of the
+// columns in the same order as Cols. Violations follow the convention of
+// CalcConViolation: they are positive if the value is below its lower bound,
+// negative if it is above its upper bound, and zero if it is within Featol of its
+// bounds. The maximum and sum of the infeasibilities are taken over the absolute
+// values of all row, bound, and integrality violations.
+type PointEval struct {
+	RowLhs     []float64  // LHS of each row
+	RowViol    []float64  // Violation of each row
+	RowStatus  []int      // EvalSatisfied, EvalViolated, or EvalTight for each row
+	BndViol    []float64  // Violation of the bounds of each column
+	IntViol    []float64  // Distance to the nearest integer of each integer column
+	ObjVal     float64    // Value of the objective function, including its constant
+	NumRowViol int        // Number of rows violated
+	NumBndViol int        // Number of columns violating their bounds
+	NumIntViol int        // Number of integer columns which are fractional
+	MaxViol    float64    // Maximum infeasibility
+	SumViol    float64    // Sum of infeasibilities
+}
+
+// VerifyReport contains the results of checking a solution against the original
+// model with VerifySolution. The violations of the rows, column bounds, and integer
+// restrictions are measured by their absolute values, and the objective function
+// by the difference between the value reported and the one calculated from the
+// original model. Worst lists the largest violations found, in decreasing order.
+type VerifyReport struct {
+	Feasible    bool          // True if all rows, bounds and integers are within Featol
+	ObjOk       bool          // True if the objective function value is within Featol
+	NumRowViol  int           // Number of rows violated
+	NumBndViol  int           // Number of columns violating their bounds
+	NumIntViol  int           // Number of integer columns which are fractional
+	MaxRowViol  float64       // Largest row violation
+	MaxBndViol  float64       // Largest bound violation
+	MaxIntViol  float64       // Largest integrality violation
+	ObjVal      float64       // Objective function value calculated from the original model
+	ObjDiff     float64       // Difference between the reported and calculated objective value
+	Worst       []VerifyItem  // Worst offenders, with the largest violation first
+}
+
+// VerifyItem is a single violation listed in a VerifyReport. Value is the activity
+// of a row, or the value of a column or of the objective function.
+type VerifyItem struct {
+	Kind   string    // VerifyRow, VerifyBnd, VerifyInt, or VerifyObj
+	Name   string    // Name of the row or column
+	Value  float64   // Value of the row, column or objective function
+	Viol   float64   // Absolute value of the violation
+}
+
+//==============================================================================
+
+// resizeEval makes sure the lists of the structure passed to the function (eval)
+// hold one item per row or column, reusing their memory if large enough.
+func resizeEval(eval *PointEval) {
+
+	resize := func(list []float64, size int) []float64 {
+		if cap(list) < size {
+			return make([]float64, size)
+		}
+		return list[:size]
+	}
+
+	eval.RowLhs  = resize(eval.RowLhs, len(Rows))
+	eval.RowViol = resize(eval.RowViol, len(Rows))
+	eval.BndViol = resize(eval.BndViol, len(Cols))
+	eval.IntViol = resize(eval.IntViol, len(Cols))
+
+	if cap(eval.RowStatus) < len(Rows) {
+		eval.RowStatus = make([]int, len(Rows))
+	} else {
+		eval.RowStatus = eval.RowStatus[:len(Rows)]
+	}
+}
+
+//==============================================================================
+
+// addViol adds the violation passed to the function (viol) to the maximum and sum
+// of the infeasibilities in the structure passed to the function (eval).
+func addViol(eval *PointEval, viol float64) {
+
+	viol = math.Abs(viol)
+	eval.SumViol += viol
+
+	if viol > eval.MaxViol {
+		eval.MaxViol = viol
+	}
+}
+
+//==============================================================================
+
+// EvaluatePoint evaluates the point passed to the function (point), which holds the
+// value of every column in the same order as Cols, against the model. The LHS,
+// violation and status of every row, the violations of the column bounds and of
+// the integer restrictions, the value of the objective function, and the number,
+// maximum and sum of the violations are returned in eval. The lists in eval are
+// reused if they are large enough, so the same structure should be passed to
+// repeated calls to avoid allocating memory.
+// In case of failure, function returns an error.
+func EvaluatePoint(point []float64, eval *PointEval) error {
+	var rhold  float64  // holder for real number during processing
+	var lhs    float64  // LHS of row being processed
+
+	if eval == nil {
+		return errors.New("EvaluatePoint received nil results structure")
+	}
+
+	if len(point) != len(Cols) {
+		return errors.Errorf("EvaluatePoint received %d values, expected %d",
+			len(point), len(Cols))
+	}
+
+	resizeEval(eval)
+
+	eval.ObjVal     = 0
+	eval.NumRowViol = 0
+	eval.NumBndViol = 0
+	eval.NumIntViol = 0
+	eval.MaxViol    = 0
+	eval.SumViol    = 0
+
+	// Calculate the LHS of all rows in a single pass over the elements.
+	for i := 0; i < len(Rows); i++ {
+		eval.RowLhs[i] = 0
+	}
+
+	for i := 0; i < len(Elems); i++ {
+		eval.RowLhs[Elems[i].InRow] += Elems[i].Value * point[Elems[i].InCol]
+	}
+
+	// Evaluate the rows in the same way as CalcConViolation.
+	for i := 0; i < len(Rows); i++ {
+		lhs = eval.RowLhs[i]
+
+		if math.IsNaN(lhs) {
+			return errors.Errorf("EvaluatePoint generated NaN for row %s", Rows[i].Name)
+		}
+
+		eval.RowViol[i]   = 0
+		eval.RowStatus[i] = EvalSatisfied
+
+		if Rows[i].Type == "N" {
+			continue
+		}
+
+		if Rows[i].RHSlo > -Plinfy {
+			rhold = Rows[i].RHSlo - lhs
+			if rhold > Featol {
+				eval.RowViol[i]   = rhold
+				eval.RowStatus[i] = EvalViolated
+			} else if rhold >= -Featol {
+				eval.RowStatus[i] = EvalTight
+			}
+		}
+
+		if eval.RowStatus[i] == EvalSatisfied && Rows[i].RHSup < Plinfy {
+			rhold = Rows[i].RHSup - lhs
+			if rhold < -Featol {
+				eval.RowViol[i]   = rhold
+				eval.RowStatus[i] = EvalViolated
+			} else if rhold <= Featol {
+				eval.RowStatus[i] = EvalTight
+			}
+		}
+
+		if eval.RowStatus[i] == EvalViolated {
+			eval.NumRowViol++
+			addViol(eval, eval.RowViol[i])
+		}
+	} // End for all rows
+
+	// Evaluate the bounds and integer restrictions of the columns.
+	for j := 0; j < len(Cols); j++ {
+		eval.BndViol[j] = 0
+		eval.IntViol[j] = 0
+
+		if point[j] < Cols[j].BndLo - Featol {
+			eval.BndViol[j] = Cols[j].BndLo - point[j]
+		} else if point[j] > Cols[j].BndUp + Featol {
+			eval.BndViol[j] = Cols[j].BndUp - point[j]
+		}
+
+		if eval.BndViol[j] != 0 {
+			eval.NumBndViol++
+			addViol(eval, eval.BndViol[j])
+		}
+
+		if Cols[j].Type != "R" {
+			rhold = math.Abs(point[j] - math.Round(point[j]))
+			if rhold > Featol {
+				eval.IntViol[j] = rhold
+				eval.NumIntViol++
+				addViol(eval, rhold)
+			}
+		}
+	} // End for all columns
+
+	if ObjRow >= 0 && ObjRow < len(Rows) {
+		eval.ObjVal = eval.RowLhs[ObjRow] - objRowConst
+	}
+
+	return nil
+}
+
+//==============================================================================
+
+// EvaluatePointMap evaluates the point passed to the function (point), which holds
+// the value of every column keyed by column name, against the model, and returns
+// the results in eval in the same way as EvaluatePoint. It is slower than
+// EvaluatePoint, since the point must first be converted to a list.
+// In case of failure, function returns an error.
+func EvaluatePointMap(point map[string]float64, eval *PointEval) error {
+
+	values := make([]float64, len(Cols))
+
+	for j := 0; j < len(Cols); j++ {
+		value, ok := point[Cols[j].Name]
+		if !ok {
+			return errors.Errorf("EvaluatePointMap received no value for column %s", Cols[j].Name)
+		}
+		values[j] = value
+	}
+
+	if err := EvaluatePoint(values, eval); err != nil {
+		return errors.Wrap(err, "EvaluatePointMap failed")
+	}
+
+	return nil
+}
+
+//==============================================================================
+
+// PrintPointEval prints the results of evaluating a point passed to the function
+// via the eval parameter: the objective function value and infeasibilities,
+// followed by the rows, bounds and integer restrictions which are violated.
+// In case of failure, function returns an error.
+func PrintPointEval(eval PointEval) error {
+
+	if len(eval.RowLhs) != len(Rows) || len(eval.BndViol) != len(Cols) {
+		return errors.New("PrintPointEval received results which do not match the model")
+	}
+
+	fmt.Printf("\nPOINT EVALUATION\n\n")
+	fmt.Printf("Objective value %e\n", eval.ObjVal)
+	fmt.Printf("Violated: %d rows, %d bounds, %d integers\n", eval.NumRowViol,
+		eval.NumBndViol, eval.NumIntViol)
+	fmt.Printf("Maximum infeasibility %e, sum %e\n", eval.MaxViol, eval.SumViol)
+
+	for i := 0; i < len(Rows); i++ {
+		if eval.RowStatus[i] == EvalViolated {
+			fmt.Printf("  Row %-16s LHS %14e  violation %14e\n", Rows[i].Name,
+				eval.RowLhs[i], eval.RowViol[i])
+		}
+	}
+
+	for j := 0; j < len(Cols); j++ {
+		if eval.BndViol[j] != 0 {
+			fmt.Printf("  Col %-16s bound violation %14e\n", Cols[j].Name, eval.BndViol[j])
+		}
+		if eval.IntViol[j] != 0 {
+			fmt.Printf("  Col %-16s integer violation %14e\n", Cols[j].Name, eval.IntViol[j])
+		}
+	}
+
+	fmt.Printf("\n")
+
+	return nil
+}
+
+//==============================================================================
+
+// VerifySolution checks the solution passed to the function (psRslt), as returned
+// by CplexSolveProb, CoinSolveProb, PostSolve or ImportSoln, against the original
+// model saved by ReduceMatrix before presolve. Every row is checked against its
+// bounds, every column against its bounds and, if integer, for integrality, and the
+// objective function value is recalculated and compared with the one reported.
+// Violations larger than Featol are counted, and the worst of them are listed by
+// name in report; the objective function differs if the difference is larger than
+// Featol relative to its value. The original model is not available if the list
+// of presolve operations was read by ReadPsopFile.
+// In case of failure, function returns an error.
+func VerifySolution(psRslt PsSoln, report *VerifyReport) error {
+	var items  []VerifyItem  // violations found
+	var rowLhs map[string]float64  // LHS of each row
+	var lhs       float64    // LHS of row being processed
+	var value     float64    // value of column being processed
+	var viol      float64    // violation being processed
+
+	if report == nil {
+		return errors.New("VerifySolution received nil report")
+	}
+
+	*report = VerifyReport{}
+
+	if psOrigCols == nil {
+		return errors.New("VerifySolution found no original model")
+	}
+
+	// Calculate the LHS of all rows from the columns of the original model.
+	rowLhs = make(map[string]float64)
+	for j := 0; j < len(psOrigCols); j++ {
+		mapItem, ok := psRslt.VarMap[psOrigCols[j].Name]
+		if !ok {
+			return errors.Errorf("VerifySolution found no value for column %s", psOrigCols[j].Name)
+		}
+		for k := 0; k < len(psOrigCols[j].Coef); k++ {
+			rowLhs[psOrigCols[j].Coef[k].Name] += psOrigCols[j].Coef[k].Value * mapItem.Value
+		}
+	}
+
+	// Check the rows against their bounds.
+	for i := 0; i < len(psOrigRows); i++ {
+		if psOrigRows[i].Type == "N" {
+			continue
+		}
+
+		// The coefficients, RHS and values are all in the units of the model as
+		// it was before presolve, scaled or not.
+		lhs = rowLhs[psOrigRows[i].Name]
+
+		viol = 0
+		if lhs < psOrigRows[i].RhsLo - Featol {
+			viol = psOrigRows[i].RhsLo - lhs
+		} else if lhs > psOrigRows[i].RhsUp + Featol {
+			viol = lhs - psOrigRows[i].RhsUp
+		}
+
+		if viol > 0 {
+			report.NumRowViol++
+			report.MaxRowViol = math.Max(report.MaxRowViol, viol)
+			items = append(items, VerifyItem{Kind: VerifyRow, Name: psOrigRows[i].Name,
+				Value: lhs, Viol: viol})
+		}
+	} // End for all rows
+
+	// Check the columns against their bounds and integer restrictions.
+	for j := 0; j < len(psOrigCols); j++ {
+		value = psRslt.VarMap[psOrigCols[j].Name].Value
+
+		viol = 0
+		if value < psOrigCols[j].BndLo - Featol {
+			viol = psOrigCols[j].BndLo - value
+		} else if value > psOrigCols[j].BndUp + Featol {
+			viol = value - psOrigCols[j].BndUp
+		}
+
+		if viol > 0 {
+			report.NumBndViol++
+			report.MaxBndViol = math.Max(report.MaxBndViol, viol)
+			items = append(items, VerifyItem{Kind: VerifyBnd, Name: psOrigCols[j].Name,
+				Value: value, Viol: viol})
+		}
+
+		if psOrigCols[j].Type != "R" {
+			viol = math.Abs(value - math.Round(value))
+			if viol > Featol {
+				report.NumIntViol++
+				report.MaxIntViol = math.Max(report.MaxIntViol, viol)
+				items = append(items, VerifyItem{Kind: VerifyInt, Name: psOrigCols[j].Name,
+					Value: value, Viol: viol})
+			}
+		}
+	} // End for all columns
+
+	// Recalculate the objective function value from the original model.
+	if psOrigObj.Name != "" {
+		if err := getPstLhs(psOrigObj, psRslt.VarMap, &report.ObjVal); err != nil {
+			return errors.Wrap(err, "VerifySolution failed to evaluate objective function")
+		}
+	}
+	report.ObjVal -= psOrigConst
+	report.ObjDiff = psRslt.ObjVal - report.ObjVal
+
+	report.Feasible = report.NumRowViol == 0 && report.NumBndViol == 0 && report.NumIntViol == 0
+	report.ObjOk    = math.Abs(report.ObjDiff) <= Featol * math.Max(1, math.Abs(report.ObjVal))
+
+	if !report.ObjOk {
+		items = append(items, VerifyItem{Kind: VerifyObj, Name: psOrigObj.Name,
+			Value: psRslt.ObjVal, Viol: math.Abs(report.ObjDiff)})
+	}
+
+	// List the worst offenders.
+	sort.SliceStable(items, func(a, b int) bool { return items[a].Viol > items[b].Viol })
+
+	if len(items) > verifyMaxWorst {
+		items = items[:verifyMaxWorst]
+	}
+	report.Worst = items
+
+	if !report.Feasible || !report.ObjOk {
+		log(pWARN, "WARNING: Solution violates %d rows, %d bounds, %d integers, objective difference %e.\n",
+			report.NumRowViol, report.NumBndViol, report.NumIntViol, report.ObjDiff)
+	}
+
+	return nil
+}
+
+//==============================================================================
+
+// PrintVerifyReport prints the verification report passed to the function via the
+// report parameter, with the number and largest violations of each kind, followed
+// by the worst offenders.
+// In case of failure, function returns an error.
+func PrintVerifyReport(report VerifyReport) error {
+
+	fmt.Printf("\nSOLUTION VERIFICATION\n\n")
+	fmt.Printf("Feasible: %t, objective consistent: %t\n", report.Feasible, report.ObjOk)
+	fmt.Printf("%-10s %8s %14s\n", "", "Number", "Largest")
+	fmt.Printf("%-10s %8d %14e\n", "Rows", report.NumRowViol, report.MaxRowViol)
+	fmt.Printf("%-10s %8d %14e\n", "Bounds", report.NumBndViol, report.MaxBndViol)
+	fmt.Printf("%-10s %8d %14e\n", "Integers", report.NumIntViol, report.MaxIntViol)
+	fmt.Printf("Objective %e, difference %e\n", report.ObjVal, report.ObjDiff)
+
+	if len(report.Worst) > 0 {
+		fmt.Printf("\nWorst offenders:\n")
+		for i := 0; i < len(report.Worst); i++ {
+			fmt.Printf("  %-4s %-16s value %14e  violation %14e\n", report.Worst[i].Kind,
+				report.Worst[i].Name, report.Worst[i].Value, report.Worst[i].Viol)
+		}
+	}
+
+	fmt.Printf("\n")
+
+	return nil
+}
+
+//============================ END OF FILE =====================================
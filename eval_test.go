@@ -0,0 +1,68 @@
+//==============================================================================
+// eval_test: TESTS of the verification of solutions
+// 01   Oct. 18, 2026   Initial version
+
+
+// The tests scale, reduce and solve the small sample LP model supplied with lporun
+// (AFIRO) with the in-process simplex solver, complete the solution by postsolve,
+// and check it against the original model with VerifySolution.
+
+package lpo
+
+import (
+	"math"
+	"testing"
+)
+
+//==============================================================================
+
+// TestVerifyScaledModel verifies the solution of AFIRO scaled with ScaleModel
+// before presolve, whose rows are saved by ReduceMatrix in scaled units.
+func TestVerifyScaledModel(t *testing.T) {
+	var psRslt  PsSoln        // solution of the original model
+	var report  VerifyReport  // verification of the solution
+	var scaled  bool          // true if any row was scaled
+
+	quietLog(t)
+	loadModel(t, "lporun/inputSmallLp.txt")
+
+	if err := ScaleModel(ScaleGeom, nil); err != nil {
+		t.Fatalf("ScaleModel: %v", err)
+	}
+	for i := 0; i < len(Rows); i++ {
+		if i != ObjRow && Rows[i].ScaleFactor != 1 {
+			scaled = true
+		}
+	}
+	if !scaled {
+		t.Fatalf("ScaleModel scaled no row")
+	}
+
+	if err := ReduceMatrix(samplePsCtrl()); err != nil {
+		t.Fatalf("ReduceMatrix: %v", err)
+	}
+
+	conMap := make(PsResConMap)
+	varMap := make(PsResVarMap)
+	solveReducedLp(t, conMap, varMap)
+
+	if err := PostSolve(conMap, varMap, &psRslt); err != nil {
+		t.Fatalf("PostSolve: %v", err)
+	}
+
+	if err := VerifySolution(psRslt, &report); err != nil {
+		t.Fatalf("VerifySolution: %v", err)
+	}
+
+	if !report.Feasible {
+		t.Errorf("solution violates %d rows (largest %e) and %d bounds (largest %e)",
+			report.NumRowViol, report.MaxRowViol, report.NumBndViol, report.MaxBndViol)
+	}
+
+	if !report.ObjOk || math.Abs(report.ObjVal + 464.753143) > 1.0e-5 {
+		t.Errorf("objective %f reported, %f calculated, want -464.753143", psRslt.ObjVal,
+			report.ObjVal)
+	}
+}
+
+//============================ END OF FILE =====================================
@@ -0,0 +1,137 @@
+//==============================================================================
+// helpers_test: HELPER functions shared by the tests
+// 01   Oct. 18, 2026   Initial version
+
+
+// This file contains the functions used by the tests of several files: loading the
+// sample models supplied with lporun quietly, the presolve controls used with them,
+// solving the reduced model with the in-process simplex solver, and checking points
+// and solutions against the model with EvaluatePoint.
+
+package lpo
+
+import (
+	"testing"
+)
+
+//==============================================================================
+
+// quietLog turns off the messages of the package until the test passed to the
+// function (t) completes, when the log level is restored.
+func quietLog(t *testing.T) {
+	var level int  // log level before the test
+
+	_ = GetLogLevel(&level)
+	_ = SetLogLevel(0)
+	t.Cleanup(func() { _ = SetLogLevel(level) })
+}
+
+//==============================================================================
+
+// loadModel replaces the model in the Rows, Cols, and Elems global variables by
+// the one read from the MPS file passed in (fileName), clearing the results of
+// presolving any earlier model.
+func loadModel(t *testing.T, fileName string) {
+
+	InitModel()
+	if err := ReadMpsFile(fileName); err != nil {
+		t.Fatalf("ReadMpsFile(%s): %v", fileName, err)
+	}
+}
+
+//==============================================================================
+
+// samplePsCtrl returns the presolve controls with all LP reductions enabled.
+func samplePsCtrl() PsCtrl {
+
+	return PsCtrl{MaxIter: 10, DelRowNonbinding: true, DelRowSingleton: true,
+		DelColSingleton: true, DelFixedVars: true, DelDupRows: true, DelDupCols: true,
+		DelForcingRows: true}
+}
+
+//==============================================================================
+
+// solveReducedLp solves the model in the Rows, Cols, and Elems global variables
+// with SimplexSolve, and returns its solution in the constraint and variable maps
+// (conMap, varMap) in the form returned by the solver interfaces.
+func solveReducedLp(t *testing.T, conMap PsResConMap, varMap PsResVarMap) {
+	var soln SpxSoln  // solution returned by the solver
+	var row    psRow  // row being added to the constraint map
+
+	if err := SimplexSolve(&soln); err != nil {
+		t.Fatalf("SimplexSolve: %v", err)
+	}
+	if soln.Status != SpxOptimal {
+		t.Fatalf("SimplexSolve status %s, want %s", soln.Status, SpxOptimal)
+	}
+
+	for i := 0; i < len(Rows); i++ {
+		if i == ObjRow {
+			continue
+		}
+		_ = translateRow(Rows[i], &row)
+		_ = addConMapItem(conMap, row)
+
+		mapItem := conMap[Rows[i].Name]
+		mapItem.Slack = row.Rhs - soln.RowAct[i]
+		mapItem.Pi    = soln.RowDual[i]
+		mapItem.Dual  = soln.RowDual[i]
+		conMap[Rows[i].Name] = mapItem
+	} // End for all rows
+
+	for j := 0; j < len(Cols); j++ {
+		mapItem := varMap[Cols[j].Name]
+		mapItem.Status      = psVarStatNA
+		mapItem.Value       = soln.ColValue[j]
+		mapItem.ReducedCost = soln.RedCost[j]
+		mapItem.ScaleFactor = Cols[j].ScaleFactor
+		varMap[Cols[j].Name] = mapItem
+	} // End for all columns
+}
+
+//==============================================================================
+
+// evalSoln evaluates the values of the columns of the solution passed in (psRslt)
+// against the model in the Rows, Cols, and Elems global variables with
+// EvaluatePoint, and returns the results in eval.
+func evalSoln(t *testing.T, psRslt PsSoln, eval *PointEval) {
+
+	point := make(map[string]float64)
+	for name, item := range psRslt.VarMap {
+		point[name] = item.Value
+	}
+
+	if err := EvaluatePointMap(point, eval); err != nil {
+		t.Fatalf("EvaluatePointMap: %v", err)
+	}
+}
+
+//==============================================================================
+
+// checkPoint evaluates the point passed in (point), listing a value for every
+// column in the same order as Cols, with EvaluatePoint, and reports every row,
+// bound, and integer restriction it violates by more than Featol. The messages
+// start with the label passed in.
+func checkPoint(t *testing.T, label string, point []float64) {
+	var eval PointEval  // results of the evaluation
+
+	if err := EvaluatePoint(point, &eval); err != nil {
+		t.Fatalf("%s: EvaluatePoint: %v", label, err)
+	}
+
+	for i := 0; i < len(Rows); i++ {
+		if eval.RowStatus[i] == EvalViolated {
+			t.Errorf("%s: row %s activity %f outside [%f, %f]", label, Rows[i].Name,
+				eval.RowLhs[i], Rows[i].RHSlo, Rows[i].RHSup)
+		}
+	} // End for all rows
+
+	for j := 0; j < len(Cols); j++ {
+		if eval.BndViol[j] != 0 || eval.IntViol[j] != 0 {
+			t.Errorf("%s: column %s value %f not integer or outside [%f, %f]", label,
+				Cols[j].Name, point[j], Cols[j].BndLo, Cols[j].BndUp)
+		}
+	} // End for all columns
+}
+
+//============================ END OF FILE =====================================
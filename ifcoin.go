@@ -38,8 +38,26 @@ type CoinSoln struct {
 	Solver     string         `xml:"general>solverInvoked"`
 	ObjVal     float64        `xml:"optimization>solution>objectives>values>obj"`
 	Varb     []CoinResVarb    `xml:"optimization>solution>variables>values>var"`
-	RedCost  []CoinResRcost   `xml:"optimization>solution>variables>other>var"`
+	Other    []CoinResOther   `xml:"optimization>solution>variables>other"`
+	RedCost  []CoinResRcost   `xml:"-"`
+	Ray      []CoinResVarb    `xml:"-"`
 	Dual     []CoinResConDual `xml:"optimization>solution>constraints>dualValues>con"`
+	Status     CoinResStatus  `xml:"optimization>solution>status"`
+}
+
+// CoinResOther holds a list of other results of the variables for the Coin-OR
+// solution, named by the solver. CoinParseSoln moves the primal ray (coinRayName)
+// to the Ray field of CoinSoln, and all other lists to the RedCost field.
+type CoinResOther struct {
+	Name        string          `xml:"name,attr"`
+	Var       []CoinResRcost    `xml:"var"`
+}
+
+// CoinResStatus holds the status of the Coin-OR solution (e.g. "optimal" or
+// "infeasible").
+type CoinResStatus struct {
+	Type        string  `xml:"type,attr"`
+	Description string  `xml:"description,attr"`
 }
 
 // CoinResConDual holds constraint dual values for the Coin-OR solution.
@@ -66,6 +84,10 @@ type CoinResRcost struct {
 // must also contain clp.exe and cbc.exe.
 var coinOrExe string = "C:/coin_dir/OSSolverService"
 
+// Name of the list of other variable results in which Coin-OR returns the primal
+// ray of an unbounded model.
+const coinRayName = "primalRay"
+
 // Package variable used internally by the keepAlive concurrent routine to shut
 // itself down and allow the main program to continue once it is halted.
 var stopRunning bool  // flag telling keepAlive function to stop running
@@ -84,8 +106,11 @@ func coinInitSoln(soln *CoinSoln) error {
 	soln.Solver     = ""
 	soln.ObjVal     = 0.0
 	soln.Varb       = nil
+	soln.Other      = nil
 	soln.RedCost    = nil
+	soln.Ray        = nil
 	soln.Dual       = nil
+	soln.Status     = CoinResStatus{}
 
 	return nil
 }
@@ -406,6 +431,133 @@ func CoinParseSoln(fileName string, soln *CoinSoln) error {
 
 	xml.Unmarshal(XMLdata, soln)
 
+	// Separate the primal ray, if any, from the reduced costs.
+	for i := 0; i < len(soln.Other); i++ {
+		if strings.EqualFold(soln.Other[i].Name, coinRayName) {
+			for k := 0; k < len(soln.Other[i].Var); k++ {
+				soln.Ray = append(soln.Ray, CoinResVarb(soln.Other[i].Var[k]))
+			}
+		} else {
+			soln.RedCost = append(soln.RedCost, soln.Other[i].Var...)
+		}
+	}
+
+	return nil
+}
+
+//==============================================================================
+
+// coinRay returns the primal ray in the Coin-OR solution passed to the function
+// (cnSoln), keyed by the names of the columns in the Cols global variable, or nil
+// if the solution has no ray.
+func coinRay(cnSoln CoinSoln) map[string]float64 {
+
+	if len(cnSoln.Ray) == 0 {
+		return nil
+	}
+
+	ray := make(map[string]float64)
+	for k := 0; k < len(cnSoln.Ray); k++ {
+		if cnSoln.Ray[k].Index >= 0 && cnSoln.Ray[k].Index < len(Cols) && cnSoln.Ray[k].Value != 0 {
+			ray[Cols[cnSoln.Ray[k].Index].Name] = cnSoln.Ray[k].Value
+		}
+	}
+
+	return ray
+}
+
+//==============================================================================
+
+// CoinFeasible sets feasible to true if Coin-OR CLP finds a feasible solution of
+// the LP relaxation of the model in the Rows, Cols, and Elems global variables.
+// The model is written to an MPS file in the temporary directory and solved with
+// CoinSolveMps. It can be passed to FindIIS as the solver function.
+// In case of failure, function returns an error.
+func CoinFeasible(feasible *bool) error {
+	var fileCoinIn   string  // MPS file for input to Coin-OR
+	var fileCoinOut  string  // xml file for Coin-OR output
+	var cnSoln     CoinSoln  // Coin-OR solution from parsed xml file
+	var err           error  // error returned by secondary functions called
+
+	*feasible   = false
+	fileCoinIn  = tempDirPath + "/CoinFeasIn.txt"
+	fileCoinOut = tempDirPath + "/CoinFeasOut.txt"
+
+	if err = WriteMpsFile(fileCoinIn); err != nil {
+		return errors.Wrap(err, "CoinFeasible failed")
+	}
+
+	if err = CoinSolveMps(fileCoinIn, fileCoinOut, "CLP", &cnSoln); err != nil {
+		return errors.Wrap(err, "CoinFeasible failed")
+	}
+
+	switch strings.ToLower(cnSoln.Status.Type) {
+	case "infeasible":
+		*feasible = false
+
+	case "optimal", "globallyoptimal", "locallyoptimal", "feasible", "bestsofar", "unbounded":
+		*feasible = true
+
+	default:
+		return errors.Errorf("CoinFeasible received solution status '%s'", cnSoln.Status.Type)
+	}
+
+	return nil
+}
+
+//==============================================================================
+
+// CoinLpSolve solves the LP relaxation of the model in the Rows, Cols, and Elems
+// global variables with Coin-OR CLP, and returns the status, the objective function
+// value, and the values of the columns in soln. The model is written to an MPS file
+// in the temporary directory and solved with CoinSolveMps. It can be passed to
+// FeasibilityPump as the solver function.
+// In case of failure, function returns an error.
+func CoinLpSolve(soln *SpxSoln) error {
+	var fileCoinIn   string  // MPS file for input to Coin-OR
+	var fileCoinOut  string  // xml file for Coin-OR output
+	var cnSoln     CoinSoln  // Coin-OR solution from parsed xml file
+	var err           error  // error returned by secondary functions called
+
+	*soln       = SpxSoln{}
+	fileCoinIn  = tempDirPath + "/CoinLpIn.txt"
+	fileCoinOut = tempDirPath + "/CoinLpOut.txt"
+
+	if err = WriteMpsFile(fileCoinIn); err != nil {
+		return errors.Wrap(err, "CoinLpSolve failed")
+	}
+
+	if err = CoinSolveMps(fileCoinIn, fileCoinOut, "CLP", &cnSoln); err != nil {
+		return errors.Wrap(err, "CoinLpSolve failed")
+	}
+
+	switch strings.ToLower(cnSoln.Status.Type) {
+	case "infeasible":
+		soln.Status = SpxInfeasible
+		return nil
+
+	case "unbounded":
+		soln.Status = SpxUnbounded
+		return nil
+
+	case "optimal", "globallyoptimal", "locallyoptimal":
+		soln.Status = SpxOptimal
+
+	default:
+		return errors.Errorf("CoinLpSolve received solution status '%s'", cnSoln.Status.Type)
+	}
+
+	if len(cnSoln.Varb) != len(Cols) {
+		return errors.Errorf("CoinLpSolve received %d variables, expected %d",
+			len(cnSoln.Varb), len(Cols))
+	}
+
+	soln.ObjVal   = cnSoln.ObjVal
+	soln.ColValue = make([]float64, len(Cols))
+	for j := 0; j < len(Cols); j++ {
+		soln.ColValue[j] = cnSoln.Varb[j].Value
+	}
+
 	return nil
 }
 
@@ -442,16 +594,25 @@ func CoinSolveProb (psc PsCtrl, psRslt *PsSoln) error {
 	var origObjFunc      psRow  // objective function before reductions in post-solve format
 	var psRows         []psRow  // original constraints translated to post-solve format
 	var err              error  // error returned by secondary functions called
+	var rhsRange    PsRangeMap  // RHS ranges of the reduced model
+	var objRange    PsRangeMap  // objective ranges of the reduced model
 	var colScaleMap  map[string]float64  // map of column scale factors in original model
 
 	// Initialize variables.
-	psOpList       = nil
+	initPsState()
 	psRslt.ObjVal  = 0
 	psRslt.ConMap  = nil
 	psRslt.VarMap  = nil
 	psRslt.RowsDel = 0
 	psRslt.ColsDel = 0
 	psRslt.ElemDel = 0
+	psRslt.Unbounded = false
+	psRslt.Ray       = nil
+	psRslt.Scaling   = ScaleReport{}
+	psRslt.Verify    = nil
+	psRslt.RhsRange  = nil
+	psRslt.ObjRange  = nil
+	psRslt.Report    = PsReport{}
 	coefPerLine    = 2
 
 	if psc.FileInMps != "" {
@@ -524,13 +685,23 @@ func CoinSolveProb (psc PsCtrl, psRslt *PsSoln) error {
 
 	// Remove rows and columns specified in the control structure and calculate
 	// how many rows, cols, and elems were removed.			
-	if err = ReduceMatrix(psc); err != nil {
+	if err = ReduceMatrixReport(psc, &psRslt.Report); err != nil {
 		return errors.Wrap(err, "CoinSolveProb failed")
 	}
 	
 	psRslt.RowsDel = numRows - len(Rows)
 	psRslt.ColsDel = numCols - len(Cols)
 	psRslt.ElemDel = numElem - len(Elems)
+	psRslt.Unbounded = psUnbounded
+
+	if psUnbounded {
+		psRslt.Ray = newUnbndError("", nil, psc.SimplexDiag).Ray
+	}
+
+	// Scale the reduced model if requested.
+	if err = scaleForSolver(psc.ScaleMethod, &psRslt.Scaling); err != nil {
+		return errors.Wrap(err, "CoinSolveProb failed")
+	}
 
 
 	// Write the reduced MPS file either to a location specified by the user, or
@@ -605,6 +776,18 @@ func CoinSolveProb (psc PsCtrl, psRslt *PsSoln) error {
 		}		
 	}
 
+	// Report an unbounded or infeasible model with the corresponding error, with
+	// the ray returned by Coin-OR.
+	switch strings.ToLower(cnSoln.Status.Type) {
+	case "unbounded":
+		err = psStatusError("Coin-OR", SpxUnbounded, coinRay(cnSoln), psc.SimplexDiag)
+	case "infeasible":
+		err = psStatusError("Coin-OR", SpxInfeasible, nil, psc.SimplexDiag)
+	}
+	if err != nil {
+		return errors.Wrap(err, "CoinSolveProb failed")
+	}
+
 /*	
 	// Parse the solution file.
 	if err = CoinParseSoln(fileCoinOut, &cnSoln); err != nil {
@@ -627,6 +810,20 @@ func CoinSolveProb (psc PsCtrl, psRslt *PsSoln) error {
 	
 
 	
+	// Calculate the ranges of the reduced LP if requested. Coin-OR does not report
+	// ranges in its solution file, so they are obtained from the in-process solver.
+	if psc.Ranging {
+		rhsRange = make(PsRangeMap)
+		objRange = make(PsRangeMap)
+		if err = rangeReduced(rhsRange, objRange); err != nil {
+			return errors.Wrap(err, "CoinSolveProb failed")
+		}
+		unscaleRange(rhsRange, objRange)
+	}
+
+	// Undo the scaling of the reduced model, if any.
+	unscaleSoln(psRslt.ConMap, psRslt.VarMap)
+
 	// Update the maps with the information deleted during presolve.
 	if err = postSolve(psRslt.ConMap, psRslt.VarMap); err != nil {
 		return errors.Wrap(err, "CoinSolveProb failed")
@@ -666,6 +863,21 @@ func CoinSolveProb (psc PsCtrl, psRslt *PsSoln) error {
 	// Adjust the objective function by the constant value.
 	psRslt.ObjVal -= objRowConst
 
+	// Map the ranges of the reduced model to the original model.
+	if psc.Ranging {
+		if err = PostSolveRange(rhsRange, objRange, psRslt); err != nil {
+			return errors.Wrap(err, "CoinSolveProb failed")
+		}
+	}
+
+	// Verify the solution against the original model if requested.
+	if psc.VerifySoln {
+		psRslt.Verify = &VerifyReport{}
+		if err = VerifySolution(*psRslt, psRslt.Verify); err != nil {
+			return errors.Wrap(err, "CoinSolveProb failed")
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,67 @@
+//==============================================================================
+// ifcoin_test: TESTS of the interface functions for Coin-OR
+// 01   Oct. 18, 2026   Initial version
+
+
+// The tests parse an xml solution file in the format written by Coin-OR for an
+// unbounded model, and check that the primal ray is separated from the reduced
+// costs.
+
+package lpo
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+//==============================================================================
+
+// TestCoinParseSolnRay checks that CoinParseSoln returns the primal ray of an
+// unbounded model in the Ray field, and the reduced costs alone in RedCost, and
+// that coinRay keys the ray by column name.
+func TestCoinParseSolnRay(t *testing.T) {
+	var cnSoln CoinSoln  // solution parsed from the file
+
+	quietLog(t)
+	fileName := filepath.Join(t.TempDir(), "osrl.xml")
+	osrl := `<?xml version="1.0" encoding="UTF-8"?>
+<osrl>
+<general><instanceName>UNBND</instanceName><solverInvoked>COIN-OR Clp</solverInvoked></general>
+<optimization numberOfSolutions="1" numberOfVariables="2" numberOfConstraints="1">
+<solution>
+<status type="unbounded"/>
+<variables>
+<values numberOfVar="2"><var idx="0">0</var><var idx="1">0</var></values>
+<other numberOfVar="2" name="reduced_costs"><var idx="0">-1</var><var idx="1">0</var></other>
+<other numberOfVar="2" name="primalRay"><var idx="0">1</var><var idx="1">0</var></other>
+</variables>
+</solution>
+</optimization>
+</osrl>
+`
+	if err := os.WriteFile(fileName, []byte(osrl), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	if err := CoinParseSoln(fileName, &cnSoln); err != nil {
+		t.Fatalf("CoinParseSoln: %v", err)
+	}
+
+	if cnSoln.Status.Type != "unbounded" {
+		t.Errorf("status %q, want unbounded", cnSoln.Status.Type)
+	}
+	if len(cnSoln.RedCost) != 2 || cnSoln.RedCost[0].Value != -1 {
+		t.Errorf("reduced costs %v, want those of both columns", cnSoln.RedCost)
+	}
+
+	Cols = []InputCol{{Name: "X"}, {Name: "Y"}}
+	t.Cleanup(func() { InitModel() })
+
+	if ray, want := coinRay(cnSoln), map[string]float64{"X": 1}; !reflect.DeepEqual(ray, want) {
+		t.Errorf("ray %v, want %v", ray, want)
+	}
+}
+
+//============================ END OF FILE =====================================
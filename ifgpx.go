@@ -20,6 +20,15 @@ import (
 	"github.com/go-opt/gpx"
 )
 
+// Values of the Cplex solution status (CPX_STAT_* and CPXMIP_*) reported in the
+// header of the solution file for unbounded or infeasible models.
+const (
+	cpxStatUnbounded  = 2    // LP is unbounded
+	cpxStatInfeasible = 3    // LP is infeasible
+	cpxMipInfeasible  = 103  // MIP is infeasible
+	cpxMipUnbounded   = 118  // MIP is unbounded
+)
+
 //==============================================================================
 // GENERAL UTILITY PRIVATE FUNCTIONS
 //==============================================================================
@@ -83,6 +92,39 @@ func buildCpxConMap(cpSoln []gpx.SolnRow, constrMap *PsResConMap) error {
 	return nil
 }
 
+//==============================================================================
+
+// cplexStatus returns in status SpxUnbounded or SpxInfeasible if Cplex found the
+// model it last optimized unbounded or infeasible, and "" otherwise. The gpx
+// package does not return the status of the solution, so it is read from the
+// solution file written by Cplex. If Cplex has no solution to write, or found the
+// model infeasible or unbounded without saying which, status is set to "".
+// The gpx package provides no access to the ray of an unbounded model.
+// In case of failure, function returns an error.
+func cplexStatus(status *string) error {
+	var cpxSoln CplexSoln  // Cplex solution parsed from the xml file
+
+	*status  = ""
+	fileName := tempDirPath + "/cpxStatus.xml"
+
+	if gpx.SolWrite(fileName) != nil {
+		return nil
+	}
+
+	if err := CplexParseSoln(fileName, &cpxSoln); err != nil {
+		return errors.Wrap(err, "cplexStatus failed")
+	}
+
+	switch cpxSoln.Header.SolStatusValue {
+	case cpxStatUnbounded, cpxMipUnbounded:
+		*status = SpxUnbounded
+	case cpxStatInfeasible, cpxMipInfeasible:
+		*status = SpxInfeasible
+	}
+
+	return nil
+}
+
 //==============================================================================
 // EXPORTED FUNCTIONS
 //==============================================================================
@@ -122,17 +164,27 @@ func CplexSolveProb (psc PsCtrl, psRslt *PsSoln) error {
 	var origObjFunc      psRow  // objective function before reductions in post-solve format
 	var psRows         []psRow  // original constraints translated to post-solve format
 	var err              error  // error returned by secondary functions called
+	var rhsRange    PsRangeMap  // RHS ranges of the reduced model
+	var objRange    PsRangeMap  // objective ranges of the reduced model
+	var status          string  // status of the Cplex solution, if unbounded or infeasible
 	var colScaleMap  map[string]float64  // map of column scale factors in original model
 	
 
 	// Initialize variables.
-	psOpList       = nil
+	initPsState()
 	psRslt.ObjVal  = 0
 	psRslt.ConMap  = nil
 	psRslt.VarMap  = nil
 	psRslt.RowsDel = 0
 	psRslt.ColsDel = 0
 	psRslt.ElemDel = 0
+	psRslt.Unbounded = false
+	psRslt.Ray       = nil
+	psRslt.Scaling   = ScaleReport{}
+	psRslt.Verify    = nil
+	psRslt.RhsRange  = nil
+	psRslt.ObjRange  = nil
+	psRslt.Report    = PsReport{}
 	coefPerLine    = 2
 
 	if psc.FileInMps != "" {
@@ -205,13 +257,23 @@ func CplexSolveProb (psc PsCtrl, psRslt *PsSoln) error {
 
 	// Remove rows and columns specified in the control structure and calculate
 	// how many rows, cols, and elems were removed.			
-	if err = ReduceMatrix(psc); err != nil {
+	if err = ReduceMatrixReport(psc, &psRslt.Report); err != nil {
 		return errors.Wrap(err, "CplexSolveProb failed")
 	}
 	
 	psRslt.RowsDel = numRows - len(Rows)
 	psRslt.ColsDel = numCols - len(Cols)
 	psRslt.ElemDel = numElem - len(Elems)
+	psRslt.Unbounded = psUnbounded
+
+	if psUnbounded {
+		psRslt.Ray = newUnbndError("", nil, psc.SimplexDiag).Ray
+	}
+
+	// Scale the reduced model if requested.
+	if err = scaleForSolver(psc.ScaleMethod, &psRslt.Scaling); err != nil {
+		return errors.Wrap(err, "CplexSolveProb failed")
+	}
 
 
 	// Write the reduced MPS file if requested.	
@@ -244,8 +306,15 @@ func CplexSolveProb (psc PsCtrl, psRslt *PsSoln) error {
 			return errors.Wrap(err, "CplexSolveProb failed to optimize MIP")				
 		}
 
+		if err = cplexStatus(&status); err != nil {
+			return errors.Wrap(err, "CplexSolveProb failed")
+		}
+		if err = psStatusError("Cplex", status, nil, psc.SimplexDiag); err != nil {
+			return errors.Wrap(err, "CplexSolveProb failed")
+		}
+
 		if err = gpx.GetMipSolution(&objVal, &sRows, &sCols); err != nil {
-			return errors.Wrap(err, "CplexSolveProb failed to get solution")				
+			return errors.Wrap(psSolverError("Cplex", err, psc.SimplexDiag), "CplexSolveProb failed to get solution")
 		}
 				
 	} else {
@@ -254,8 +323,15 @@ func CplexSolveProb (psc PsCtrl, psRslt *PsSoln) error {
 			return errors.Wrap(err, "CplexSolveProb failed to optimize LP")				
 		}
 
+		if err = cplexStatus(&status); err != nil {
+			return errors.Wrap(err, "CplexSolveProb failed")
+		}
+		if err = psStatusError("Cplex", status, nil, psc.SimplexDiag); err != nil {
+			return errors.Wrap(err, "CplexSolveProb failed")
+		}
+
 		if err = gpx.GetSolution(&objVal, &sRows, &sCols); err != nil {
-			return errors.Wrap(err, "CplexSolveProb failed to get solution")				
+			return errors.Wrap(psSolverError("Cplex", err, psc.SimplexDiag), "CplexSolveProb failed to get solution")
 		}
 		
 	} // End else this is LP
@@ -284,6 +360,21 @@ func CplexSolveProb (psc PsCtrl, psRslt *PsSoln) error {
 		return errors.Wrap(err, "CplexSolveProb failed to close cplex")
 	}
 	
+	// Calculate the ranges of the reduced LP if requested. The gpx package does not
+	// provide sensitivity analysis, so the ranges are obtained from Cplex through
+	// the file interface.
+	if psc.Ranging {
+		rhsRange = make(PsRangeMap)
+		objRange = make(PsRangeMap)
+		if err = cplexRange(rhsRange, objRange); err != nil {
+			return errors.Wrap(err, "CplexSolveProb failed")
+		}
+		unscaleRange(rhsRange, objRange)
+	}
+
+	// Undo the scaling of the reduced model, if any.
+	unscaleSoln(psRslt.ConMap, psRslt.VarMap)
+
 	// Update the maps with the information deleted during presolve.
 	if err = postSolve(psRslt.ConMap, psRslt.VarMap); err != nil {
 		return errors.Wrap(err, "CplexSolveProb failed")
@@ -321,6 +412,21 @@ func CplexSolveProb (psc PsCtrl, psRslt *PsSoln) error {
 	}
 
 	psRslt.ObjVal -= objRowConst
+
+	// Map the ranges of the reduced model to the original model.
+	if psc.Ranging {
+		if err = PostSolveRange(rhsRange, objRange, psRslt); err != nil {
+			return errors.Wrap(err, "CplexSolveProb failed")
+		}
+	}
+
+	// Verify the solution against the original model if requested.
+	if psc.VerifySoln {
+		psRslt.Verify = &VerifyReport{}
+		if err = VerifySolution(*psRslt, psRslt.Verify); err != nil {
+			return errors.Wrap(err, "CplexSolveProb failed")
+		}
+	}
 		
 	return nil
 }
@@ -637,6 +743,93 @@ func TransFromGpx(probNm string, objNm string, gRows []gpx.InputRow, gCols []gpx
 
 //==============================================================================
 
+// CplexFeasible sets feasible to true if Cplex finds a feasible solution of the
+// LP relaxation of the model in the Rows, Cols, and Elems global variables. The
+// model is solved as an LP, and it is considered infeasible if Cplex returns no
+// solution. It can be passed to FindIIS as the solver function.
+// In case of failure, function returns an error.
+func CplexFeasible(feasible *bool) error {
+	var objVal    float64       // objective function value
+	var sRows []gpx.SolnRow     // solution rows
+	var sCols []gpx.SolnCol     // solution columns
+	var err           error     // error returned by secondary functions called
+
+	*feasible = false
+
+	if err = CplexCreateProb(); err != nil {
+		return errors.Wrap(err, "CplexFeasible failed")
+	}
+
+	if err = gpx.LpOpt(); err != nil {
+		_ = gpx.CloseCplex()
+		return errors.Wrap(err, "CplexFeasible failed to optimize LP")
+	}
+
+	// Cplex has no solution to return if the model is infeasible.
+	*feasible = gpx.GetSolution(&objVal, &sRows, &sCols) == nil
+
+	if err = gpx.CloseCplex(); err != nil {
+		return errors.Wrap(err, "CplexFeasible failed to close cplex")
+	}
+
+	return nil
+}
+
+//==============================================================================
+
+// CplexLpSolve solves the LP relaxation of the model in the Rows, Cols, and Elems
+// global variables with Cplex, and returns the status, the objective function
+// value, and the values of the columns in soln. The model is considered infeasible
+// if Cplex returns no solution. It can be passed to FeasibilityPump as the solver
+// function.
+// In case of failure, function returns an error.
+func CplexLpSolve(soln *SpxSoln) error {
+	var objVal    float64       // objective function value
+	var sRows []gpx.SolnRow     // solution rows
+	var sCols []gpx.SolnCol     // solution columns
+	var colIndex map[string]int // index of each column by name
+	var err           error     // error returned by secondary functions called
+
+	*soln = SpxSoln{}
+
+	if err = CplexCreateProb(); err != nil {
+		return errors.Wrap(err, "CplexLpSolve failed")
+	}
+
+	if err = gpx.LpOpt(); err != nil {
+		_ = gpx.CloseCplex()
+		return errors.Wrap(err, "CplexLpSolve failed to optimize LP")
+	}
+
+	// Cplex has no solution to return if the model is infeasible.
+	if gpx.GetSolution(&objVal, &sRows, &sCols) != nil {
+		soln.Status = SpxInfeasible
+	} else {
+		soln.Status   = SpxOptimal
+		soln.ObjVal   = objVal
+		soln.ColValue = make([]float64, len(Cols))
+
+		colIndex = make(map[string]int)
+		for j := 0; j < len(Cols); j++ {
+			colIndex[Cols[j].Name] = j
+		}
+
+		for k := 0; k < len(sCols); k++ {
+			if j, ok := colIndex[sCols[k].Name]; ok {
+				soln.ColValue[j] = sCols[k].Value
+			}
+		}
+	}
+
+	if err = gpx.CloseCplex(); err != nil {
+		return errors.Wrap(err, "CplexLpSolve failed to close cplex")
+	}
+
+	return nil
+}
+
+//==============================================================================
+
 // CplexCreateProb initializes the Cplex environment, translates the model from
 // the global Rows, Cols, and Elems variables to data structures used by the gpx
 // package, and uses gpx to build the model in Cplex so that it may be solved by
@@ -0,0 +1,537 @@
+//==============================================================================
+// iis: Irreducible Infeasible Subsystem functions
+// 01   Oct. 18, 2026   Initial version
+
+
+// This file contains functions which find an irreducible infeasible subsystem
+// (IIS) of an infeasible model, i.e. a set of rows and column bounds which has no
+// feasible solution, but which becomes feasible if any one of them is removed.
+//
+// The candidate set is first reduced by presolve: if ReduceMatrix finds the model
+// to be infeasible, the rows named in the chain of bound derivations are often
+// already infeasible on their own. The candidate set is then reduced to an IIS by
+// a deletion or additive filter, which solves a sequence of submodels with a
+// solver function that only reports whether the submodel is feasible. The IIS is
+// that of the LP relaxation of the model, so integer restrictions are ignored.
+
+package lpo
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+)
+
+
+// IisSoln contains the irreducible infeasible subsystem returned by FindIIS. The
+// rows and columns are listed by name in the order in which they occur in the model.
+type IisSoln struct {
+	Rows      []string  // Rows included in the IIS
+	ColsLo    []string  // Columns whose lower bound is included in the IIS
+	ColsUp    []string  // Columns whose upper bound is included in the IIS
+	NumSolves int       // Number of times the solver function was called
+}
+
+// FeasSolver is the type of the solver function used by FindIIS. It sets feasible
+// to true if the model in the Rows, Cols, and Elems global variables has a
+// feasible solution, and returns an error if the solver failed. SimplexFeasible,
+// CoinFeasible, and CplexFeasible can be used, as can any function of this type.
+type FeasSolver func(feasible *bool) error
+
+// Filters used by FindIIS to reduce the candidate set to an IIS
+const (
+	IisDeletion = "Deletion"  // Remove items one at a time, keeping those needed
+	IisAdditive = "Additive"  // Add items one at a time until infeasible
+)
+
+// Kinds of items of which an IIS is made
+const (
+	iisRow   = 0  // Row
+	iisColLo = 1  // Lower bound of column
+	iisColUp = 2  // Upper bound of column
+)
+
+// iisItem is used internally to identify a row or column bound of the model.
+type iisItem struct {
+	Kind   int  // iisRow, iisColLo, or iisColUp
+	Index  int  // Index of the row or column in the model
+}
+
+//==============================================================================
+
+// iisBuildModel replaces the model in the global variables by the submodel of the
+// saved model made of the items passed to the function (items). The rows not in
+// the list are removed, the bounds of columns not in the list are relaxed to
+// infinity, and columns which do not occur in any of the rows are removed. The
+// objective function is kept as an empty row, and all columns are made continuous.
+func iisBuildModel(saved modelSnapshot, items []iisItem) {
+	var rowIn   []bool  // true if row is in the submodel
+	var loIn    []bool  // true if lower bound of column is in the submodel
+	var upIn    []bool  // true if upper bound of column is in the submodel
+	var colMap   []int  // index of column in the submodel, or -1
+	var index     int   // index of element being processed
+	var newRow InputRow // row being added to the submodel
+	var newCol InputCol // column being added to the submodel
+
+	rowIn  = make([]bool, len(saved.rows))
+	loIn   = make([]bool, len(saved.cols))
+	upIn   = make([]bool, len(saved.cols))
+	colMap = make([]int, len(saved.cols))
+
+	for k := 0; k < len(items); k++ {
+		switch items[k].Kind {
+		case iisRow:
+			rowIn[items[k].Index] = true
+		case iisColLo:
+			loIn[items[k].Index] = true
+		case iisColUp:
+			upIn[items[k].Index] = true
+		}
+	}
+
+	Rows        = nil
+	Cols        = nil
+	Elems       = nil
+	ObjRow      = 0
+	objRowConst = 0
+	Name        = saved.name
+
+	objName := "OBJ"
+	if saved.objRow >= 0 {
+		objName = saved.rows[saved.objRow].Name
+	}
+	Rows = append(Rows, InputRow{Name: objName, State: stateActive, Type: "N", ScaleFactor: 1})
+
+	for j := 0; j < len(saved.cols); j++ {
+		colMap[j] = -1
+	}
+
+	for i := 0; i < len(saved.rows); i++ {
+		if !rowIn[i] {
+			continue
+		}
+
+		newRow          = saved.rows[i]
+		newRow.HasElems = nil
+		Rows = append(Rows, newRow)
+
+		for k := 0; k < len(saved.rows[i].HasElems); k++ {
+			index = saved.rows[i].HasElems[k]
+			j    := saved.elems[index].InCol
+
+			if colMap[j] < 0 {
+				newCol          = saved.cols[j]
+				newCol.Type     = "R"
+				newCol.HasElems = nil
+				if !loIn[j] {
+					newCol.BndLo = -Plinfy
+				}
+				if !upIn[j] {
+					newCol.BndUp = Plinfy
+				}
+				colMap[j] = len(Cols)
+				Cols = append(Cols, newCol)
+			}
+
+			Elems = append(Elems, InputElem{InRow: len(Rows) - 1, InCol: colMap[j],
+				Value: saved.elems[index].Value})
+			Rows[len(Rows) - 1].HasElems = append(Rows[len(Rows) - 1].HasElems, len(Elems) - 1)
+			Cols[colMap[j]].HasElems     = append(Cols[colMap[j]].HasElems, len(Elems) - 1)
+		} // End for all elements in row
+	} // End for all rows
+}
+
+//==============================================================================
+
+// iisFeasible builds the submodel made of the items passed to the function (items)
+// and sets feasible to true if the solver (solver) finds it to be feasible. A
+// submodel without rows is feasible, since reversed bounds are checked by FindIIS,
+// and is not passed to the solver.
+// In case of failure, function returns an error.
+func iisFeasible(saved modelSnapshot, items []iisItem, solver FeasSolver, numSolves *int,
+	feasible *bool) error {
+
+	iisBuildModel(saved, items)
+
+	*feasible = true
+	if len(Elems) == 0 {
+		return nil
+	}
+
+	*numSolves++
+	if err := solver(feasible); err != nil {
+		return errors.Wrap(err, "iisFeasible failed")
+	}
+
+	return nil
+}
+
+//==============================================================================
+
+// iisPresolve runs presolve on the saved model and, if it finds the model to be
+// infeasible, returns in items the rows named by the infeasibility and its chain
+// of bound derivations, together with the bounds of all columns of those rows.
+// If presolve does not find the model infeasible, items is left empty.
+func iisPresolve(saved modelSnapshot, allItems []iisItem, items *[]iisItem) {
+	var inIis  map[string]bool  // names of rows in the candidate set
+	var colIn  []bool           // true if column occurs in a candidate row
+	var infErr *PsInfeasError   // infeasibility found by presolve
+
+	*items = nil
+	restoreModelSnapshot(saved)
+	initPsState()
+
+	psCtrl := PsCtrl{MaxIter: 20, DelRowNonbinding: true, DelRowSingleton: true,
+		DelFixedVars: true, DelDupRows: true, DelForcingRows: true}
+
+	err := ReduceMatrix(psCtrl)
+	if err == nil {
+		return
+	}
+
+	infErr, ok := errors.Cause(err).(*PsInfeasError)
+	if !ok {
+		log(pDEB, "IIS presolve failed: %v\n", err)
+		return
+	}
+
+	inIis = make(map[string]bool)
+	if infErr.Row != "" {
+		inIis[infErr.Row] = true
+	}
+	for k := 0; k < len(infErr.Chain); k++ {
+		if infErr.Chain[k].Row != "" {
+			inIis[infErr.Chain[k].Row] = true
+		}
+	}
+
+	colIn = make([]bool, len(saved.cols))
+	for i := 0; i < len(saved.rows); i++ {
+		if !inIis[saved.rows[i].Name] {
+			continue
+		}
+		for k := 0; k < len(saved.rows[i].HasElems); k++ {
+			colIn[saved.elems[saved.rows[i].HasElems[k]].InCol] = true
+		}
+	}
+
+	for k := 0; k < len(allItems); k++ {
+		if allItems[k].Kind == iisRow && inIis[saved.rows[allItems[k].Index].Name] ||
+			allItems[k].Kind != iisRow && colIn[allItems[k].Index] {
+			*items = append(*items, allItems[k])
+		}
+	}
+
+	log(pINFO, "IIS presolve found %d candidate rows and bounds.\n", len(*items))
+}
+
+//==============================================================================
+
+// iisDeletion reduces the infeasible set of items (items) to an IIS by removing
+// each item in turn, and putting it back if the remaining items are feasible.
+// In case of failure, function returns an error.
+func iisDeletion(saved modelSnapshot, solver FeasSolver, numSolves *int, items *[]iisItem) error {
+	var feasible bool       // true if submodel is feasible
+	var trial  []iisItem    // items without the one being tested
+
+	for k := 0; k < len(*items); {
+		trial = append(trial[:0], (*items)[:k]...)
+		trial = append(trial, (*items)[k + 1:]...)
+
+		if err := iisFeasible(saved, trial, solver, numSolves, &feasible); err != nil {
+			return errors.Wrap(err, "iisDeletion failed")
+		}
+
+		if feasible {
+			k++
+		} else {
+			*items = append((*items)[:0], trial...)
+		}
+	} // End for all items
+
+	return nil
+}
+
+//==============================================================================
+
+// iisAdditive reduces the infeasible set of items (items) to an IIS. Starting from
+// an empty set, items are added in turn until the set becomes infeasible, and the
+// last item added is kept in the IIS. This is repeated until the items kept are
+// infeasible on their own.
+// In case of failure, function returns an error.
+func iisAdditive(saved modelSnapshot, solver FeasSolver, numSolves *int, items *[]iisItem) error {
+	var feasible bool      // true if submodel is feasible
+	var kept   []iisItem   // items kept in the IIS
+	var inKept []bool      // true if item is kept
+	var trial  []iisItem   // items being tested
+
+	inKept = make([]bool, len(*items))
+
+	for {
+		if err := iisFeasible(saved, kept, solver, numSolves, &feasible); err != nil {
+			return errors.Wrap(err, "iisAdditive failed")
+		}
+		if !feasible {
+			break
+		}
+
+		trial = append(trial[:0], kept...)
+		found := false
+
+		for k := 0; k < len(*items); k++ {
+			if inKept[k] {
+				continue
+			}
+
+			trial = append(trial, (*items)[k])
+			if err := iisFeasible(saved, trial, solver, numSolves, &feasible); err != nil {
+				return errors.Wrap(err, "iisAdditive failed")
+			}
+
+			if !feasible {
+				kept      = append(kept, (*items)[k])
+				inKept[k] = true
+				found     = true
+				break
+			}
+		} // End for all items not kept
+
+		if !found {
+			return errors.New("iisAdditive found candidate set to be feasible")
+		}
+	} // End while kept items are feasible
+
+	*items = kept
+
+	return nil
+}
+
+//==============================================================================
+
+// FindIIS finds an irreducible infeasible subsystem (IIS) of the LP relaxation of
+// the model in the Rows, Cols, and Elems global variables, and returns it in iis.
+// The candidate set is first reduced by presolve, and then reduced to an IIS by
+// the filter specified (IisDeletion or IisAdditive), which calls the solver
+// function (solver) to find whether submodels are feasible. The model is restored
+// before the function returns, and presolve operations recorded for it are kept.
+// In case of failure, or if the model is feasible, the function returns an error.
+func FindIIS(solver FeasSolver, filter string, iis *IisSoln) error {
+	var saved modelSnapshot  // model being analyzed
+	var allItems  []iisItem  // all rows and finite bounds of the model
+	var items     []iisItem  // candidate items for the IIS
+	var feasible       bool  // true if submodel is feasible
+	var err           error  // error returned by secondary functions called
+
+	*iis = IisSoln{}
+
+	if filter != IisDeletion && filter != IisAdditive {
+		return errors.Errorf("FindIIS received unknown filter %s", filter)
+	}
+
+	saveModelSnapshot(&saved)
+	defer restoreModelSnapshot(saved)
+
+	// A column with reversed bounds, or an empty row whose bounds exclude zero,
+	// is an IIS on its own.
+	for j := 0; j < len(Cols); j++ {
+		if Cols[j].BndLo > Cols[j].BndUp + Featol {
+			iis.ColsLo = append(iis.ColsLo, Cols[j].Name)
+			iis.ColsUp = append(iis.ColsUp, Cols[j].Name)
+			return nil
+		}
+	}
+
+	for i := 0; i < len(Rows); i++ {
+		if i != ObjRow && Rows[i].Type != "N" && len(Rows[i].HasElems) == 0 &&
+			(Rows[i].RHSlo > Featol || Rows[i].RHSup < -Featol) {
+			iis.Rows = append(iis.Rows, Rows[i].Name)
+			return nil
+		}
+	}
+
+	for i := 0; i < len(Rows); i++ {
+		if i != ObjRow && Rows[i].Type != "N" && len(Rows[i].HasElems) > 0 {
+			allItems = append(allItems, iisItem{Kind: iisRow, Index: i})
+		}
+	}
+
+	for j := 0; j < len(Cols); j++ {
+		if Cols[j].BndLo > -Plinfy {
+			allItems = append(allItems, iisItem{Kind: iisColLo, Index: j})
+		}
+		if Cols[j].BndUp < Plinfy {
+			allItems = append(allItems, iisItem{Kind: iisColUp, Index: j})
+		}
+	}
+
+	// Use the candidate set found by presolve if it is infeasible, otherwise
+	// start from the whole model.
+	iisPresolve(saved, allItems, &items)
+
+	if len(items) > 0 {
+		if err = iisFeasible(saved, items, solver, &iis.NumSolves, &feasible); err != nil {
+			return errors.Wrap(err, "FindIIS failed")
+		}
+		if feasible {
+			items = nil
+		}
+	}
+
+	if len(items) == 0 {
+		items = allItems
+		if err = iisFeasible(saved, items, solver, &iis.NumSolves, &feasible); err != nil {
+			return errors.Wrap(err, "FindIIS failed")
+		}
+		if feasible {
+			return errors.New("FindIIS found the model to be feasible")
+		}
+	}
+
+	if filter == IisDeletion {
+		err = iisDeletion(saved, solver, &iis.NumSolves, &items)
+	} else {
+		err = iisAdditive(saved, solver, &iis.NumSolves, &items)
+	}
+	if err != nil {
+		return errors.Wrap(err, "FindIIS failed")
+	}
+
+	// List the items in the order of the model.
+	for k := 0; k < len(allItems); k++ {
+		for l := 0; l < len(items); l++ {
+			if items[l] != allItems[k] {
+				continue
+			}
+			switch items[l].Kind {
+			case iisRow:
+				iis.Rows = append(iis.Rows, saved.rows[items[l].Index].Name)
+			case iisColLo:
+				iis.ColsLo = append(iis.ColsLo, saved.cols[items[l].Index].Name)
+			case iisColUp:
+				iis.ColsUp = append(iis.ColsUp, saved.cols[items[l].Index].Name)
+			}
+		}
+	} // End for all items of the model
+
+	log(pINFO, "FindIIS found %d rows and %d bounds with %d solves.\n", len(iis.Rows),
+		len(iis.ColsLo) + len(iis.ColsUp), iis.NumSolves)
+
+	return nil
+}
+
+//==============================================================================
+
+// iisItems returns the items of the model in the global variables which are
+// named in the IIS (iis).
+// In case of failure, function returns an error.
+func iisItems(iis IisSoln, items *[]iisItem) error {
+
+	rowIndex := make(map[string]int)
+	for i := 0; i < len(Rows); i++ {
+		rowIndex[Rows[i].Name] = i
+	}
+
+	colIndex := make(map[string]int)
+	for j := 0; j < len(Cols); j++ {
+		colIndex[Cols[j].Name] = j
+	}
+
+	*items = nil
+
+	for k := 0; k < len(iis.Rows); k++ {
+		i, ok := rowIndex[iis.Rows[k]]
+		if !ok {
+			return errors.Errorf("Row %s of IIS not found in model", iis.Rows[k])
+		}
+		*items = append(*items, iisItem{Kind: iisRow, Index: i})
+	}
+
+	for k := 0; k < len(iis.ColsLo) + len(iis.ColsUp); k++ {
+		kind := iisColLo
+		name := ""
+		if k < len(iis.ColsLo) {
+			name = iis.ColsLo[k]
+		} else {
+			kind = iisColUp
+			name = iis.ColsUp[k - len(iis.ColsLo)]
+		}
+
+		j, ok := colIndex[name]
+		if !ok {
+			return errors.Errorf("Column %s of IIS not found in model", name)
+		}
+		*items = append(*items, iisItem{Kind: kind, Index: j})
+	}
+
+	return nil
+}
+
+//==============================================================================
+
+// PrintIIS prints the irreducible infeasible subsystem (iis) returned by FindIIS.
+// The rows are printed in equation format as by PrintRow, followed by the column
+// bounds. The rows and columns must be in the model in the global variables.
+// In case of failure, the function returns an error.
+func PrintIIS(iis IisSoln) error {
+	var items []iisItem  // items of the IIS in the model
+	var err      error   // error returned by secondary functions called
+
+	if err = iisItems(iis, &items); err != nil {
+		return errors.Wrap(err, "PrintIIS failed")
+	}
+
+	fmt.Printf("\nIIS of model %s found with %d solves:\n\n", Name, iis.NumSolves)
+
+	fmt.Printf("Rows: %d\n", len(iis.Rows))
+	for k := 0; k < len(items); k++ {
+		if items[k].Kind == iisRow {
+			if err = PrintRow(items[k].Index); err != nil {
+				return errors.Wrap(err, "PrintIIS failed")
+			}
+		}
+	}
+
+	fmt.Printf("\nBounds: %d\n", len(iis.ColsLo) + len(iis.ColsUp))
+	fmt.Printf("%s) %15s %5s %15s\n", "Index", "Column Name", "Bound", "Value")
+
+	for k := 0; k < len(items); k++ {
+		j := items[k].Index
+		switch items[k].Kind {
+		case iisColLo:
+			fmt.Printf("%5d) %15s %5s %15e\n", j, Cols[j].Name, "L", Cols[j].BndLo)
+		case iisColUp:
+			fmt.Printf("%5d) %15s %5s %15e\n", j, Cols[j].Name, "U", Cols[j].BndUp)
+		}
+	}
+
+	return nil
+}
+
+//==============================================================================
+
+// WriteIisMpsFile writes the irreducible infeasible subsystem (iis) returned by
+// FindIIS to the MPS file specified (fileName). The file contains the rows of the
+// IIS, the columns occurring in them, and the bounds of the IIS, with an empty
+// objective function. The model in the global variables is not changed.
+// In case of failure, the function returns an error.
+func WriteIisMpsFile(iis IisSoln, fileName string) error {
+	var saved modelSnapshot  // model from which the IIS was found
+	var items     []iisItem  // items of the IIS in the model
+	var err           error  // error returned by secondary functions called
+
+	if err = iisItems(iis, &items); err != nil {
+		return errors.Wrap(err, "WriteIisMpsFile failed")
+	}
+
+	saveModelSnapshot(&saved)
+	defer restoreModelSnapshot(saved)
+
+	iisBuildModel(saved, items)
+
+	if err = WriteMpsFile(fileName); err != nil {
+		return errors.Wrap(err, "WriteIisMpsFile failed")
+	}
+
+	return nil
+}
+
+//============================ END OF FILE =====================================
@@ -149,6 +149,47 @@ type Statistics struct {
 	TotBnds      int       // Number of binding bounds	
 }
 
+// ScaleReport contains the results of the scaling performed by ScaleModel. It lists
+// the method used, the number of passes, and the smallest and largest absolute
+// values of the coefficients of the constraints before and after scaling.
+type ScaleReport struct {
+	Method     string    // Scaling method used
+	Passes     int       // Number of passes performed
+	MinBefore  float64   // Smallest absolute coefficient before scaling
+	MaxBefore  float64   // Largest absolute coefficient before scaling
+	MinAfter   float64   // Smallest absolute coefficient after scaling
+	MaxAfter   float64   // Largest absolute coefficient after scaling
+}
+
+// modelSnapshot is used internally to save the model and the results of presolve,
+// so that they can be restored after other models are built and solved in the
+// global variables.
+type modelSnapshot struct {
+	rows      []InputRow   // Rows of model
+	cols      []InputCol   // Columns of model
+	elems     []InputElem  // Elements of model
+	objRow      int        // Index of objective function row
+	objConst    float64    // Constant of objective function
+	name        string     // Name of model
+	opList    []psOp       // Presolve operations
+	origSaved   bool       // Model before presolve was saved
+	unbounded   bool       // Presolve found the model unbounded
+	unbndCol    string     // Column found unbounded by presolve
+	unbndRay    map[string]float64  // Ray found by presolve
+	scaleRow    map[string]float64  // Row scale factors applied to reduced model
+	scaleCol    map[string]float64  // Column scale factors applied to reduced model
+	origObj     psRow      // Objective function before presolve
+	origRows  []psRow      // Rows before presolve
+	origCols  []psCol      // Columns before presolve
+	origConst   float64    // Objective function constant before presolve
+	bndHist   []psBndStep  // Bound derivations of presolve
+	bndLoCur    map[string]int    // Latest derivations of lower bounds
+	bndUpCur    map[string]int    // Latest derivations of upper bounds
+	bndRowFix   map[string][]int  // Derivations of bounds of columns fixed in rows
+	implList  []PsImpl     // Implications found by probing
+	cliqueList []PsClique  // Clique table
+}
+
 // Global variables exported by this package.
 var (
     Name    string         // Name of the problem
@@ -183,6 +224,22 @@ const (
 	conTypeMc1  = 3  // Binding constraints are multiple choice	
 )
 
+// Scaling methods used by ScaleModel
+const (
+	ScaleEquil      = "EQUIL"  // Equilibration, divide by the largest coefficient
+	ScaleGeom       = "GEOM"   // Divide by the geometric mean of largest and smallest coefficient
+	ScaleArith      = "ARITH"  // Divide by the arithmetic mean of the coefficients
+	ScaleCurtisReid = "CR"     // Curtis-Reid least squares scaling of the logarithms
+)
+
+// Constants which limit the number of passes made by ScaleModel
+const (
+	scaleMaxPasses = 20    // Maximum number of passes
+	scaleImprove   = 0.9   // Passes stop if the coefficient ratio is not below this fraction of its last value
+	scaleCrMaxIter = 100   // Maximum conjugate gradient iterations of Curtis-Reid scaling
+	scaleCrTol     = 1e-4  // Relative residual at which conjugate gradient iterations stop
+)
+
 // Constants which control the level of detail when printing messages.
 // Everything up to and including logLevel is printed, anything above does not.
 // For no output, logLevel must be set to 0.
@@ -258,8 +315,9 @@ func SetTempDirPath(dirPath string) error {
 // GENERAL UTILITY FUNCTIONS
 //==============================================================================
 
-// InitModel initializes the global structures which store the model. In case of
-// failure, an error is returned. 
+// InitModel initializes the global structures which store the model, and clears
+// the presolve operations recorded for an earlier model. In case of failure, an
+// error is returned. 
 func InitModel() error {
 
 	//Initialize constraint structure.
@@ -302,6 +360,8 @@ func InitModel() error {
 	Cols        = nil
 	Elems       = nil
 
+	initPsState()
+
 	// If the values of Plinfy and Featol have not been set, then set them to 
 	// the default values.
 	if Plinfy == 0.0 {
@@ -316,6 +376,89 @@ func InitModel() error {
 
 //==============================================================================
 
+// saveModelSnapshot saves a copy of the model and of the results of presolve in the
+// global variables to saved, so that they can be restored by restoreModelSnapshot.
+func saveModelSnapshot(saved *modelSnapshot) {
+
+	saved.rows = make([]InputRow, len(Rows))
+	copy(saved.rows, Rows)
+	for i := 0; i < len(Rows); i++ {
+		saved.rows[i].HasElems = append([]int(nil), Rows[i].HasElems...)
+	}
+
+	saved.cols = make([]InputCol, len(Cols))
+	copy(saved.cols, Cols)
+	for j := 0; j < len(Cols); j++ {
+		saved.cols[j].HasElems = append([]int(nil), Cols[j].HasElems...)
+	}
+
+	saved.elems      = append([]InputElem(nil), Elems...)
+	saved.objRow     = ObjRow
+	saved.objConst   = objRowConst
+	saved.name       = Name
+	saved.opList     = psOpList
+	saved.origSaved  = psOrigSaved
+	saved.unbounded  = psUnbounded
+	saved.unbndCol   = psUnbndCol
+	saved.unbndRay   = psUnbndRay
+	saved.scaleRow   = psScaleRow
+	saved.scaleCol   = psScaleCol
+	saved.origObj    = psOrigObj
+	saved.origRows   = psOrigRows
+	saved.origCols   = psOrigCols
+	saved.origConst  = psOrigConst
+	saved.bndHist    = psBndHist
+	saved.bndLoCur   = psBndLoCur
+	saved.bndUpCur   = psBndUpCur
+	saved.bndRowFix  = psBndRowFix
+	saved.implList   = psImplList
+	saved.cliqueList = psCliqueList
+}
+
+//==============================================================================
+
+// restoreModelSnapshot restores the model and the results of presolve saved by
+// saveModelSnapshot to the global variables. The saved copy is not modified, so it
+// can be restored more than once.
+func restoreModelSnapshot(saved modelSnapshot) {
+
+	Rows = make([]InputRow, len(saved.rows))
+	copy(Rows, saved.rows)
+	for i := 0; i < len(Rows); i++ {
+		Rows[i].HasElems = append([]int(nil), saved.rows[i].HasElems...)
+	}
+
+	Cols = make([]InputCol, len(saved.cols))
+	copy(Cols, saved.cols)
+	for j := 0; j < len(Cols); j++ {
+		Cols[j].HasElems = append([]int(nil), saved.cols[j].HasElems...)
+	}
+
+	Elems        = append([]InputElem(nil), saved.elems...)
+	ObjRow       = saved.objRow
+	objRowConst  = saved.objConst
+	Name         = saved.name
+	psOpList     = saved.opList
+	psOrigSaved  = saved.origSaved
+	psUnbounded  = saved.unbounded
+	psUnbndCol   = saved.unbndCol
+	psUnbndRay   = saved.unbndRay
+	psScaleRow   = saved.scaleRow
+	psScaleCol   = saved.scaleCol
+	psOrigObj    = saved.origObj
+	psOrigRows   = saved.origRows
+	psOrigCols   = saved.origCols
+	psOrigConst  = saved.origConst
+	psBndHist    = saved.bndHist
+	psBndLoCur   = saved.bndLoCur
+	psBndUpCur   = saved.bndUpCur
+	psBndRowFix  = saved.bndRowFix
+	psImplList   = saved.implList
+	psCliqueList = saved.cliqueList
+}
+
+//==============================================================================
+
 // calcGadVec calculates the gradient vector length and gradient vector length
 // squated values and sets the fields to these values in each constraint of the
 // exported global variable.
@@ -469,7 +612,7 @@ func AdjustModel() error {
 func snap(numIn float64, boundType string) (snapValue float64) {	
 	var down float64  // closest integer value lower than number passed in
 	
-	down = math.Trunc(numIn)
+	down = math.Floor(numIn)
 	// Do this if the input number is close enough to integer
 	if numIn-down <= Featol {
 		return down
@@ -616,88 +759,50 @@ func CalcConViolation(rowIndex int, point []float64, viol *float64, status *int)
 
 //==============================================================================
 
-// TODO: This function is not working and needs to be looked at and fixed.
-//
-// scaleCols performs equilibriation scaling on the columns by dividing through 
-// by the largest element. Only a single pass is done. Changes are made to the 
-// global Cols data structure. 
-// In case of failure, it returns an error. 
-func scaleCols() error {
-	var maxValue  float64  // maximum coefficient value for column
-	var minValue  float64  // minimum coefficient value
-	var gMaxValue float64  // global maximum coefficient value
-	var rhold     float64  // holder for real number during processing
-	var iel       int      // index of item in elements list
-
-	log(pINFO, "Scaling columns.\n")
-
-
-	gMaxValue = 0.0
-	minValue  = Plinfy
-	
-	// Process all columns in the model
-	for i := 0; i < len(Cols); i++ {
-
-		maxValue = 0.0
-				
-		// Find the largest absolute value coefficient for this column
-		for j := 0; j < len(Cols[i].HasElems); j++ {
-
-			iel   = Cols[i].HasElems[j]
-			rhold = math.Abs(Elems[iel].Value)
-			
-			if rhold > maxValue {
-				maxValue = rhold
-			}
-						
-			if rhold < minValue {
-				minValue = rhold
-			}
-		} // End for searching for largest absolute value coefficient
+// scaleRow divides the row specified by rowIndex by the factor passed to the
+// function (factor), and multiplies its scale factor by the same value.
+func scaleRow(rowIndex int, factor float64) {
+	var iel int  // index of item in elements list
 
-		// We will divide by maxValue, so if it is not needed (1) or not safe (0),
-		// skip this column.
+	Rows[rowIndex].ScaleFactor = Rows[rowIndex].ScaleFactor * factor
 
-		if maxValue > gMaxValue {
-			gMaxValue = maxValue
-		}
-		
-		if  maxValue == 1.0 || maxValue <= 0.0 {
-			continue
-		}
+	if Rows[rowIndex].RHSlo > -Plinfy && Rows[rowIndex].RHSlo < Plinfy {
+		Rows[rowIndex].RHSlo = Rows[rowIndex].RHSlo / factor
+	}
 
-		// Update the scale factor for this column and update the bounds if needed.
+	if Rows[rowIndex].RHSup > -Plinfy && Rows[rowIndex].RHSup < Plinfy {
+		Rows[rowIndex].RHSup = Rows[rowIndex].RHSup / factor
+	}
 
-		Cols[i].ScaleFactor = Cols[i].ScaleFactor * maxValue
+	for j := 0; j < len(Rows[rowIndex].HasElems); j++ {
+		iel = Rows[rowIndex].HasElems[j]
+		Elems[iel].Value = Elems[iel].Value / factor
+	}
+}
 
-		if Cols[i].BndLo > -Plinfy && Cols[i].BndLo < Plinfy {
-			//fmt.Printf("Before: i = %d, BndLo = %f, maxVal = %f\n", i, Cols[i].BndLo, maxValue)
-			Cols[i].BndLo = Cols[i].BndLo / maxValue
-			//fmt.Printf("After:  i = %d, BndLo = %f, maxVal = %f\n", i, Cols[i].BndLo, maxValue)
-		}
-		
-		if Cols[i].BndUp > -Plinfy && Cols[i].BndUp < Plinfy {
-			//fmt.Printf("Before: i = %d, BndUp = %f, maxVal = %f\n", i, Cols[i].BndUp, maxValue)
-			Cols[i].BndUp = Cols[i].BndUp / maxValue
-			//fmt.Printf("After:  i = %d, BndUp = %f, maxVal = %f\n", i, Cols[i].BndUp, maxValue)
-		}
-		
-		// Divide all coefficients by the maximum value.
+//==============================================================================
 
-		for j := 0; j < len(Cols[i].HasElems); j++ {
-			iel = Cols[i].HasElems[j]
-			Elems[iel].Value = Elems[iel].Value / maxValue
-		}		
-				
-	} // End for all columns
+// scaleCol divides the coefficients of the column specified by colIndex by the
+// factor passed to the function (factor), and multiplies its scale factor by the
+// same value. The variable of the scaled column is the original variable times
+// the factor, so its bounds are multiplied by the factor.
+func scaleCol(colIndex int, factor float64) {
+	var iel int  // index of item in elements list
 
-	// Recalculate the gradient vector for all rows.
+	Cols[colIndex].ScaleFactor = Cols[colIndex].ScaleFactor * factor
 
-	_ = calcGradVec()
+	if Cols[colIndex].BndLo > -Plinfy && Cols[colIndex].BndLo < Plinfy {
+		Cols[colIndex].BndLo = Cols[colIndex].BndLo * factor
+	}
 
-	log(pINFO, "Lowest column scale factor %f, highest %f.\n", minValue, gMaxValue)
+	if Cols[colIndex].BndUp > -Plinfy && Cols[colIndex].BndUp < Plinfy {
+		Cols[colIndex].BndUp = Cols[colIndex].BndUp * factor
+	}
 
-	return nil
+	for j := 0; j < len(Cols[colIndex].HasElems); j++ {
+		iel = Cols[colIndex].HasElems[j]
+		Elems[iel].Value = Elems[iel].Value / factor
+	}
 }
 
 //==============================================================================
@@ -734,35 +839,446 @@ func ScaleRows() error {
 			continue
 		}
 
-		// Update the scale factor and, if needed, RHS values for this row.
-		
-		Rows[i].ScaleFactor = Rows[i].ScaleFactor * maxValue
+		// Update the scale factor, RHS values, and coefficients for this row.
+		scaleRow(i, maxValue)
+				
+	} // End for all rows
+
+	_ = calcGradVec()
+
+	return nil	
+}
+
+//==============================================================================
+
+// scaleValue returns the scale factor of a row or column calculated by the method
+// specified (ScaleEquil, ScaleGeom, or ScaleArith) from the largest and smallest
+// absolute values of its coefficients (maxValue, minValue) and their sum (sumValue)
+// over the number of coefficients (count). The factor is rounded to a power of 2,
+// so that scaling does not introduce rounding errors, and 1 is returned if the
+// row or column has no coefficients.
+func scaleValue(method string, maxValue float64, minValue float64, sumValue float64,
+	count int) float64 {
+	var factor float64  // scale factor before rounding
+
+	if count == 0 || maxValue <= 0 {
+		return 1
+	}
+
+	switch method {
+	case ScaleEquil:
+		factor = maxValue
+	case ScaleGeom:
+		factor = math.Sqrt(maxValue * minValue)
+	case ScaleArith:
+		factor = sumValue / float64(count)
+	default:
+		return 1
+	}
 
-		if Rows[i].RHSlo > -Plinfy && Rows[i].RHSlo < Plinfy {
-			Rows[i].RHSlo = Rows[i].RHSlo / maxValue
+	return math.Exp2(math.Round(math.Log2(factor)))
+}
+
+//==============================================================================
+
+// coefRange returns the smallest (minValue) and largest (maxValue) absolute value
+// of the coefficients of the constraints, excluding the objective function and
+// other non-binding rows. Both are set to 0 if there are no coefficients.
+func coefRange(minValue *float64, maxValue *float64) {
+	var rhold float64  // holder for real number during processing
+
+	*maxValue = 0
+	*minValue = math.Inf(1)
+
+	for i := 0; i < len(Elems); i++ {
+		if Elems[i].InRow == ObjRow || Rows[Elems[i].InRow].Type == "N" {
+			continue
+		}
+		rhold = math.Abs(Elems[i].Value)
+		if rhold == 0 {
+			continue
 		}
+		*maxValue = math.Max(*maxValue, rhold)
+		*minValue = math.Min(*minValue, rhold)
+	}
+
+	if *maxValue == 0 {
+		*minValue = 0
+	}
+}
+
+//==============================================================================
+
+// coefRatio returns the ratio of the largest to the smallest absolute value of the
+// coefficients of the constraints, excluding the objective function and other
+// non-binding rows, or 1 if there are none.
+func coefRatio() float64 {
+	var maxValue float64  // largest absolute value
+	var minValue float64  // smallest absolute value
+
+	coefRange(&minValue, &maxValue)
+
+	if maxValue == 0 {
+		return 1
+	}
+
+	return maxValue / minValue
+}
 
-		if Rows[i].RHSup > -Plinfy && Rows[i].RHSup < Plinfy {
-			Rows[i].RHSup = Rows[i].RHSup / maxValue
+//==============================================================================
+
+// scaleCurtisReid finds the row and column scale factors of the Curtis-Reid method
+// and returns them in rowFactor and colFactor, which must have the lengths of Rows
+// and Cols. The logarithms of the factors minimize the sum of the squares of the
+// base 2 logarithms of the absolute values of the scaled coefficients. The normal
+// equations of this least squares problem are solved by the conjugate gradient
+// method, preconditioned by their diagonal, which holds the number of coefficients
+// of each row and column. Rows and columns which are not scaled by ScaleModel keep
+// a factor of 1. The factors are rounded to powers of 2.
+// In case of failure, function returns an error.
+func scaleCurtisReid(rowFactor []float64, colFactor []float64) error {
+	var numRows    int        // number of rows, offset of columns in the vectors
+	var size       int        // number of unknowns
+	var active     []bool     // true if the row or column is scaled
+	var count      []float64  // number of coefficients of each row and column
+	var sol        []float64  // solution, logarithms of the factors
+	var res        []float64  // residual of the normal equations
+	var dir        []float64  // search direction
+	var prod       []float64  // product of normal matrix and search direction
+	var pres       []float64  // preconditioned residual
+	var rz         float64    // product of residual and preconditioned residual
+	var rzNew      float64    // rz for the new residual
+	var resNorm    float64    // squared norm of residual
+	var rhsNorm    float64    // squared norm of right hand side
+	var alpha      float64    // step length
+	var dAd        float64    // product of direction with product
+	var rhold      float64    // holder for real number during processing
+	var iter       int        // iteration of conjugate gradient method
+
+	if len(rowFactor) != len(Rows) || len(colFactor) != len(Cols) {
+		return errors.New("scaleCurtisReid received factor lists of wrong length")
+	}
+
+	numRows = len(Rows)
+	size    = numRows + len(Cols)
+	active  = make([]bool, size)
+	count   = make([]float64, size)
+	sol     = make([]float64, size)
+	res     = make([]float64, size)
+	dir     = make([]float64, size)
+	prod    = make([]float64, size)
+	pres    = make([]float64, size)
+
+	for i := 0; i < numRows; i++ {
+		active[i] = i != ObjRow && Rows[i].Type != "N"
+	}
+
+	for j := 0; j < len(Cols); j++ {
+		active[numRows + j] = Cols[j].Type == "R"
+	}
+
+	// inMatrix returns true if the element is used to calculate the factors.
+	inMatrix := func(iel int) bool {
+		return active[Elems[iel].InRow] && Elems[iel].Value != 0
+	}
+
+	// The right hand side holds the sums of the logarithms of each row and column,
+	// and the diagonal the number of coefficients. The logarithms of integer
+	// columns are fixed at 0, so they only add to the rows.
+	for iel := 0; iel < len(Elems); iel++ {
+		if !inMatrix(iel) {
+			continue
 		}
+		rhold = math.Log2(math.Abs(Elems[iel].Value))
+		res[Elems[iel].InRow]   += rhold
+		count[Elems[iel].InRow] += 1
+		if active[numRows + Elems[iel].InCol] {
+			res[numRows + Elems[iel].InCol]   += rhold
+			count[numRows + Elems[iel].InCol] += 1
+		}
+	}
 
-		// Divide each coefficient for this row by max value, and remember
-		// the total sum so we can update the gradient vector length.
+	// multiply sets prod to the product of the normal matrix and dir.
+	multiply := func() {
+		for k := 0; k < size; k++ {
+			prod[k] = count[k] * dir[k]
+		}
+		for iel := 0; iel < len(Elems); iel++ {
+			if !inMatrix(iel) || !active[numRows + Elems[iel].InCol] {
+				continue
+			}
+			prod[Elems[iel].InRow]           += dir[numRows + Elems[iel].InCol]
+			prod[numRows + Elems[iel].InCol] += dir[Elems[iel].InRow]
+		}
+	}
 
-		rhold = 0.0
-		
-		for j := 0; j < len(Rows[i].HasElems); j++ {
-			iel = Rows[i].HasElems[j]
-			Elems[iel].Value = Elems[iel].Value / maxValue
-			rhold += Elems[iel].Value * Elems[iel].Value
+	rz      = 0
+	rhsNorm = 0
+	for k := 0; k < size; k++ {
+		if count[k] > 0 {
+			pres[k] = res[k] / count[k]
 		}
-		
-		Rows[i].GradVecLenSq = rhold
-		Rows[i].GradVecLen   = math.Sqrt(rhold)
-				
-	} // End for all rows
+		dir[k]   = pres[k]
+		rz      += res[k] * pres[k]
+		rhsNorm += res[k] * res[k]
+	}
 
-	return nil	
+	for iter = 1; iter <= scaleCrMaxIter && rz > 0; iter++ {
+		multiply()
+
+		dAd = 0
+		for k := 0; k < size; k++ {
+			dAd += dir[k] * prod[k]
+		}
+		if dAd <= 0 {
+			break
+		}
+		alpha = rz / dAd
+
+		rzNew   = 0
+		resNorm = 0
+		for k := 0; k < size; k++ {
+			sol[k] += alpha * dir[k]
+			res[k] -= alpha * prod[k]
+			if count[k] > 0 {
+				pres[k] = res[k] / count[k]
+			}
+			rzNew   += res[k] * pres[k]
+			resNorm += res[k] * res[k]
+		}
+
+		log(pDEB, "  Curtis-Reid iteration %d, residual %e.\n", iter, math.Sqrt(resNorm))
+
+		if resNorm <= scaleCrTol * scaleCrTol * rhsNorm {
+			break
+		}
+
+		for k := 0; k < size; k++ {
+			dir[k] = pres[k] + rzNew / rz * dir[k]
+		}
+		rz = rzNew
+	} // End for conjugate gradient iterations
+
+	// The solution is only unique up to a constant added to the rows and subtracted
+	// from the columns, so the rows are shifted by the mean of their fractional
+	// parts, taken on the unit circle, before they are rounded. The column factors
+	// which are optimal for the rounded row factors are then found before rounding
+	// them, so that models which can be scaled exactly are.
+	sinSum := 0.0
+	cosSum := 0.0
+	for i := 0; i < numRows; i++ {
+		if active[i] && count[i] > 0 {
+			sinSum += math.Sin(2 * math.Pi * sol[i])
+			cosSum += math.Cos(2 * math.Pi * sol[i])
+		}
+	}
+	rhold = math.Atan2(sinSum, cosSum) / (2 * math.Pi)
+
+	for i := 0; i < numRows; i++ {
+		if active[i] && count[i] > 0 {
+			sol[i] = math.Round(sol[i] - rhold)
+		} else {
+			sol[i] = 0
+		}
+		rowFactor[i] = math.Exp2(sol[i])
+	}
+
+	for j := 0; j < len(Cols); j++ {
+		sol[numRows + j] = 0
+	}
+
+	for iel := 0; iel < len(Elems); iel++ {
+		if inMatrix(iel) && active[numRows + Elems[iel].InCol] {
+			sol[numRows + Elems[iel].InCol] += math.Log2(math.Abs(Elems[iel].Value)) - sol[Elems[iel].InRow]
+		}
+	}
+
+	for j := 0; j < len(Cols); j++ {
+		if count[numRows + j] > 0 {
+			sol[numRows + j] = sol[numRows + j] / count[numRows + j]
+		}
+		colFactor[j] = math.Exp2(math.Round(sol[numRows + j]))
+	}
+
+	return nil
+}
+
+//==============================================================================
+
+// ScaleModel scales the rows and columns of the model with the method specified
+// (ScaleEquil, ScaleGeom, ScaleArith, or ScaleCurtisReid). For the first three,
+// each row is divided by its scale factor, and then each column, calculated from
+// the absolute values of their coefficients as the largest value (equilibration),
+// the geometric mean of the largest and smallest values, or the arithmetic mean of
+// all values. Equilibration is done in a single pass, while the other methods are
+// repeated until the ratio of the largest to the smallest coefficient no longer
+// improves significantly. The Curtis-Reid method finds all factors in one pass,
+// by solving the least squares problem which brings the logarithms of the
+// absolute values of the coefficients as close as possible to 0. Factors
+// are rounded to powers of 2. The objective function and other non-binding rows
+// are not scaled, nor are integer columns, and objective coefficients do not
+// contribute to the column factors. The factors applied are multiplied into the
+// ScaleFactor of the rows and columns, so that a variable of the scaled model is
+// the original variable times its ScaleFactor, and a row of the scaled model is
+// the original row divided by its ScaleFactor. The range of the coefficients
+// before and after scaling is returned in scReport, unless it is nil.
+// In case of failure, function returns an error.
+func ScaleModel(method string, scReport *ScaleReport) error {
+	var maxValue   []float64  // largest absolute coefficient of each row or column
+	var minValue   []float64  // smallest absolute coefficient of each row or column
+	var sumValue   []float64  // sum of absolute coefficients of each row or column
+	var count          []int  // number of coefficients of each row or column
+	var rowFactor  []float64  // Curtis-Reid row scale factors
+	var colFactor  []float64  // Curtis-Reid column scale factors
+	var report   ScaleReport  // report of the scaling performed
+	var ratio        float64  // ratio of largest to smallest coefficient
+	var lastRatio    float64  // ratio before the last pass
+	var rhold        float64  // holder for real number during processing
+	var factor       float64  // scale factor of row or column
+	var pass             int  // number of passes performed
+
+	if method != ScaleEquil && method != ScaleGeom && method != ScaleArith &&
+		method != ScaleCurtisReid {
+		return errors.Errorf("ScaleModel received unknown method %s", method)
+	}
+
+	// collect finds the largest, smallest, and total absolute values of the
+	// coefficients of each row (byRow true) or column.
+	collect := func(byRow bool, size int) {
+		maxValue = make([]float64, size)
+		minValue = make([]float64, size)
+		sumValue = make([]float64, size)
+		count    = make([]int, size)
+
+		for k := 0; k < size; k++ {
+			minValue[k] = math.Inf(1)
+		}
+
+		for i := 0; i < len(Elems); i++ {
+			if Elems[i].InRow == ObjRow || Rows[Elems[i].InRow].Type == "N" {
+				continue
+			}
+			rhold = math.Abs(Elems[i].Value)
+			if rhold == 0 {
+				continue
+			}
+
+			k := Elems[i].InCol
+			if byRow {
+				k = Elems[i].InRow
+			}
+			maxValue[k]  = math.Max(maxValue[k], rhold)
+			minValue[k]  = math.Min(minValue[k], rhold)
+			sumValue[k] += rhold
+			count[k]++
+		}
+	}
+
+	report.Method = method
+	coefRange(&report.MinBefore, &report.MaxBefore)
+	ratio = coefRatio()
+	log(pINFO, "Scaling model by %s, coefficient ratio %e.\n", method, ratio)
+
+	if method == ScaleCurtisReid {
+		rowFactor = make([]float64, len(Rows))
+		colFactor = make([]float64, len(Cols))
+
+		if err := scaleCurtisReid(rowFactor, colFactor); err != nil {
+			return errors.Wrap(err, "ScaleModel failed")
+		}
+
+		for i := 0; i < len(Rows); i++ {
+			if rowFactor[i] != 1 {
+				scaleRow(i, rowFactor[i])
+			}
+		}
+
+		for j := 0; j < len(Cols); j++ {
+			if colFactor[j] != 1 {
+				scaleCol(j, colFactor[j])
+			}
+		}
+
+		ratio = coefRatio()
+	}
+
+	for pass = 1; method != ScaleCurtisReid && pass <= scaleMaxPasses; pass++ {
+		lastRatio = ratio
+
+		collect(true, len(Rows))
+		for i := 0; i < len(Rows); i++ {
+			if i == ObjRow || Rows[i].Type == "N" {
+				continue
+			}
+			factor = scaleValue(method, maxValue[i], minValue[i], sumValue[i], count[i])
+			if factor != 1 {
+				scaleRow(i, factor)
+			}
+		} // End for all rows
+
+		collect(false, len(Cols))
+		for j := 0; j < len(Cols); j++ {
+			if Cols[j].Type != "R" {
+				continue
+			}
+			factor = scaleValue(method, maxValue[j], minValue[j], sumValue[j], count[j])
+			if factor != 1 {
+				scaleCol(j, factor)
+			}
+		} // End for all columns
+
+		ratio = coefRatio()
+		log(pDEB, "  Pass %d, coefficient ratio %e.\n", pass, ratio)
+
+		if method == ScaleEquil || ratio > scaleImprove * lastRatio {
+			break
+		}
+	} // End for all passes
+
+	if pass > scaleMaxPasses {
+		pass = scaleMaxPasses
+	}
+
+	_ = calcGradVec()
+
+	report.Passes = pass
+	coefRange(&report.MinAfter, &report.MaxAfter)
+	if scReport != nil {
+		*scReport = report
+	}
+
+	log(pINFO, "Scaling done after %d passes, coefficient ratio %e.\n", pass, ratio)
+
+	return nil
+}
+
+//==============================================================================
+
+// PrintScaleReport prints the scaling report passed to the function via the
+// scReport parameter, with the range of the coefficients before and after scaling.
+// In case of failure, function returns an error.
+func PrintScaleReport(scReport ScaleReport) error {
+	var ratio float64  // ratio of largest to smallest coefficient
+
+	fmt.Printf("\nSCALING REPORT\n\n")
+	fmt.Printf("Method %s, %d passes\n", scReport.Method, scReport.Passes)
+	fmt.Printf("%-8s %14s %14s %14s\n", "", "Smallest", "Largest", "Ratio")
+
+	ratio = 1
+	if scReport.MinBefore > 0 {
+		ratio = scReport.MaxBefore / scReport.MinBefore
+	}
+	fmt.Printf("%-8s %14e %14e %14e\n", "Before", scReport.MinBefore, scReport.MaxBefore, ratio)
+
+	ratio = 1
+	if scReport.MinAfter > 0 {
+		ratio = scReport.MaxAfter / scReport.MinAfter
+	}
+	fmt.Printf("%-8s %14e %14e %14e\n", "After", scReport.MinAfter, scReport.MaxAfter, ratio)
+
+	fmt.Printf("\n")
+
+	return nil
 }
 
 //==============================================================================
@@ -770,7 +1286,12 @@ func ScaleRows() error {
 // TightenBounds tightens the bounds on the variables by executing multiple passes 
 // until no more tightenings can be made. Function accepts the maximum number of
 // of passes (maxRounds) to be performed, and returns the number of rounds that were
-// actually performed (numRounds). In case of failure, it also returns an error.
+// actually performed (numRounds). When called by ReduceMatrix, each bound tightened
+// is added to the list of presolve operations together with the row from which it
+// was derived, so that the dual values can be recovered during postsolve. If the
+// bounds of a variable are reversed, the model is infeasible and the error returned
+// is a *PsInfeasError explaining how the bounds were derived. In case of failure,
+// it also returns an error.
 func TightenBounds(maxRounds int, numRounds *int) error {
 	var colChanged     []bool  // list of columns where adjustments made in last round
 	var checkCon       []bool  // list of constraints where variables changed last time
@@ -786,11 +1307,24 @@ func TightenBounds(maxRounds int, numRounds *int) error {
 	var totAdjustments    int  // cumulative total of all adjustments made
 	var numElInRow        int  // number of elements in current row
 	var rowType        string  // holds the value of the row type since it's checked a few times
+	var colMinInf      []bool  // true if minimum of column in current row is infinite
+	var colMaxInf      []bool  // true if maximum of column in current row is infinite
+	var numMinInf         int  // number of columns in current row with infinite minimum
+	var numMaxInf         int  // number of columns in current row with infinite maximum
+	var prevBnd       float64  // bound of column before it was tightened
 
 	checkCon = make([]bool, len(Rows))
 	colChanged = make([]bool, len(Cols))
 	*numRounds = 0
 
+	// Outside of presolve, the bounds are derived from the model as it is.
+	if !psRunning {
+		psBndHist   = nil
+		psBndLoCur  = nil
+		psBndUpCur  = nil
+		psBndRowFix = nil
+	}
+
 	// Initially we check all rows and all columns
 	for icon := 0; icon < len(Rows); icon++ {
 		checkCon[icon] = true
@@ -822,6 +1356,10 @@ func TightenBounds(maxRounds int, numRounds *int) error {
 			colMax = nil
 			colMin = make([]float64, numElInRow)
 			colMax = make([]float64, numElInRow)
+			colMinInf = make([]bool, numElInRow)
+			colMaxInf = make([]bool, numElInRow)
+			numMinInf = 0
+			numMaxInf = 0
 			for i := 0; i < numElInRow; i++ {
 				// Get the coefficient times lower and upper bounds on variables.
 				//icol = Rows[icon].ElCol[i]
@@ -835,9 +1373,22 @@ func TightenBounds(maxRounds int, numRounds *int) error {
 				if rhold > rhold1 {
 					colMax[i] = rhold
 					colMin[i] = rhold1
+					colMaxInf[i] = math.Abs(Cols[Elems[index].InCol].BndLo) >= Plinfy
+					colMinInf[i] = math.Abs(Cols[Elems[index].InCol].BndUp) >= Plinfy
 				} else {
 					colMax[i] = rhold1
 					colMin[i] = rhold
+					colMaxInf[i] = math.Abs(Cols[Elems[index].InCol].BndUp) >= Plinfy
+					colMinInf[i] = math.Abs(Cols[Elems[index].InCol].BndLo) >= Plinfy
+				}
+
+				// Infinite bounds are counted rather than added to the activity, 
+				// since their product with the coefficient is not meaningful.
+				if colMinInf[i] {
+					numMinInf++
+				}
+				if colMaxInf[i] {
+					numMaxInf++
 				}
 			}
 
@@ -848,6 +1399,11 @@ func TightenBounds(maxRounds int, numRounds *int) error {
 					index = Rows[icon].HasElems[i]
 					icol  = Elems[index].InCol
 					rhold = 0.0
+					// The bound can only be derived if the other columns have a
+					// finite minimum.
+					if numMinInf > 1 || (numMinInf == 1 && !colMinInf[i]) {
+						continue
+					}
 					for j := 0; j < numElInRow; j++ {
 						if j == i {
 							continue
@@ -866,10 +1422,13 @@ func TightenBounds(maxRounds int, numRounds *int) error {
 								colChanged[icol] = true
 								anotherRound = true
 								numAdjustments++
+								recPsBndTight(icon, icol, Elems[index].Value, "U")
+								prevBnd = Cols[icol].BndUp
 								Cols[icol].BndUp = tempUp
 								if Cols[icol].Type == "I" {
 									Cols[icol].BndUp = snap(Cols[icol].BndUp, "U")
 								}
+								recBndStep(icol, "U", prevBnd, icon, "U")
 								log(pTRC, "Upper bound on variable %d %s reduced to %f.\n", icol, Cols[icol].Name, Cols[icol].BndUp)
 							}
 						} else {
@@ -880,16 +1439,24 @@ func TightenBounds(maxRounds int, numRounds *int) error {
 								colChanged[icol] = true
 								anotherRound = true
 								numAdjustments++
+								recPsBndTight(icon, icol, Elems[index].Value, "L")
+								prevBnd = Cols[icol].BndLo
 								Cols[icol].BndLo = tempLo
 								if Cols[icol].Type == "I" {
 									Cols[icol].BndLo = snap(Cols[icol].BndLo, "L")
 								}
+								recBndStep(icol, "L", prevBnd, icon, "U")
 								log(pTRC, "Lower bound on variable %d %s increased to %f.\n", icol, Cols[icol].Name, Cols[icol].BndLo)
 							}
 						} // end else lower bound may need adjusting
+						if Cols[icol].BndLo > Cols[icol].BndUp && Cols[icol].BndLo <= Cols[icol].BndUp + Featol {
+							// Reversal within tolerance is due to rounding, the variable is fixed.
+							Cols[icol].BndLo = Cols[icol].BndUp
+						}
 						if Cols[icol].BndLo > Cols[icol].BndUp {
 							log(pERR, "ERROR: Infeasible, bounds reversal on %d - %s.\n", icol, Cols[icol].Name)
-							return errors.Errorf("TightenBounds infeasible, bounds reversal on %s", Cols[icol].Name)
+							return psInfeasible(fmt.Sprintf("TightenBounds infeasible, bounds reversal on %s",
+								Cols[icol].Name), Rows[icon].Name, Cols[icol].Name, colBndRoots(Cols[icol].Name))
 						}
 					} // end if upper row bound needs adjusting
 				} // end for all elements in row
@@ -902,6 +1469,11 @@ func TightenBounds(maxRounds int, numRounds *int) error {
 					index = Rows[icon].HasElems[i]
 					icol  = Elems[index].InCol
 					rhold = 0.0
+					// The bound can only be derived if the other columns have a
+					// finite maximum.
+					if numMaxInf > 1 || (numMaxInf == 1 && !colMaxInf[i]) {
+						continue
+					}
 					for j := 0; j < numElInRow; j++ {
 						if j == i {
 							continue
@@ -920,10 +1492,13 @@ func TightenBounds(maxRounds int, numRounds *int) error {
 								colChanged[icol] = true
 								anotherRound = true
 								numAdjustments++
+								recPsBndTight(icon, icol, Elems[index].Value, "L")
+								prevBnd = Cols[icol].BndLo
 								Cols[icol].BndLo = tempLo
 								if Cols[icol].Type == "I" {
 									Cols[icol].BndLo = snap(Cols[icol].BndLo, "L")
 								}
+								recBndStep(icol, "L", prevBnd, icon, "L")
 								log(pTRC, "Lower bound on variable %d %s increased to %f.\n", icol, Cols[icol].Name, Cols[icol].BndLo)
 							}
 						} else {
@@ -933,16 +1508,24 @@ func TightenBounds(maxRounds int, numRounds *int) error {
 								colChanged[icol] = true
 								anotherRound = true
 								numAdjustments++
+								recPsBndTight(icon, icol, Elems[index].Value, "U")
+								prevBnd = Cols[icol].BndUp
 								Cols[icol].BndUp = tempUp
 								if Cols[icol].Type == "I" {
 									Cols[icol].BndUp = snap(Cols[icol].BndUp, "U")
 								}
+								recBndStep(icol, "U", prevBnd, icon, "L")
 								log(pTRC, "Upper bound on variable %d %s reduced to %f.\n", icol, Cols[icol].Name, Cols[icol].BndUp)
 							}
 						} // end else upper bound on column is tightened
+						if Cols[icol].BndLo > Cols[icol].BndUp && Cols[icol].BndLo <= Cols[icol].BndUp + Featol {
+							// Reversal within tolerance is due to rounding, the variable is fixed.
+							Cols[icol].BndLo = Cols[icol].BndUp
+						}
 						if Cols[icol].BndLo > Cols[icol].BndUp {
 							log(pERR, "ERROR: Infeasible, bounds reversal on %d - %s.\n", icol, Cols[icol].Name)
-							return errors.Errorf("TightenBounds infeasible, bounds reversal on %s", Cols[icol].Name)
+							return psInfeasible(fmt.Sprintf("TightenBounds infeasible, bounds reversal on %s",
+								Cols[icol].Name), Rows[icon].Name, Cols[icol].Name, colBndRoots(Cols[icol].Name))
 						} // end if coefficient is positive
 					} // end if row lower bound needs adjusting
 				} // end for all elements in row
@@ -1289,7 +1872,7 @@ func PrintRow(index int) error {
 	
 	line = fmt.Sprintf("%4d) %8s: ", index, Rows[index].Name)
 	
-	if len(Rows[index].HasElems) >= 0 {
+	if len(Rows[index].HasElems) > 0 {
 		// Construct the first coef * variable pair.
 		iCol = Elems[Rows[index].HasElems[0]].InCol
 		coef = Elems[Rows[index].HasElems[0]].Value
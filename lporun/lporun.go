@@ -192,6 +192,7 @@ func wpPauseOutput() error {
 // In case of failure, function returns an error.
 func wpShowProb() error {
 	var psCtrl          lpo.PsCtrl  // control structure for reductions
+	var psReport      lpo.PsReport  // report of the reductions performed
 	var err                  error  // error received from called functions
 
 	fmt.Printf("\nThis example illustrates how to read the model definition from an\n")
@@ -241,10 +242,14 @@ func wpShowProb() error {
 		return errors.Wrap(err, "wpShowProb aborted")				
 	}
 
-	if err = lpo.ReduceMatrix(psCtrl); err != nil {
+	if err = lpo.ReduceMatrixReport(psCtrl, &psReport); err != nil {
 		return errors.Wrap(err, "wpShowProb failed to reduce matrix")		
 	}			
 
+	if err = lpo.PrintPsReport(psReport); err != nil {
+		return errors.Wrap(err, "wpShowProb failed to print presolve report")		
+	}			
+
 	if err = lpo.WriteMpsFile(outRedMtx); err != nil {
 		return errors.Wrap(err, "wpShowProb failed to write reduce matrix file")		
 	}			
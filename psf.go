@@ -12,12 +12,16 @@
 package lpo
 
 import (
+	"bufio"
 	"encoding/xml"
 	"fmt"
 	"github.com/pkg/errors"
+	"io"
 	"io/ioutil"
+	"math"
 	"os"
 	"os/exec"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -37,45 +41,139 @@ type PsCtrl struct {
 	DelRowSingleton   bool    // Controls if row singletons are removed
 	DelColSingleton   bool    // Controls if column singletons are removed
 	DelFixedVars      bool    // Controls if fixed variables are removed
+	DelDupRows        bool    // Controls if duplicate (parallel) rows are removed
+	DelDupCols        bool    // Controls if duplicate (parallel) columns are merged
+	DelForcingRows    bool    // Controls if forcing and redundant rows are removed
+	TightenCoefs      bool    // Controls if coefficients of MILP rows are tightened
+	Probe             bool    // Controls if binary variables of MILP models are probed
+	ProbeMaxCols      int     // Maximum number of columns probed, or 0 for no limit
+	ProbeTimeLimit    float64 // Maximum total time in seconds spent probing, or 0 for no limit
+	MergeCliques      bool    // Controls if clique rows of MILP models are extended and merged
+	ScaleMethod       string  // Scaling of reduced model (ScaleEquil, ScaleGeom, ScaleArith, ScaleCurtisReid), or "" for none
+	VerifySoln        bool    // Controls if the solution is verified against the original model
+	Ranging           bool    // Controls if RHS and objective ranging of LP models is calculated
+	SimplexDiag       bool    // Controls if SimplexSolve diagnoses a reduced model the solver failed on
 	RunSolver         bool    // Controls if problem is to be solved by the solver 		
 }
 
 // PsSoln returns the results from CplexSolveProb or CoinSolveProb to the caller. 
 // It contains the value of the objective function, the row and column maps of the 
-// LP, and the numbers of rows, columns, and elements that were removed during 
-// presolve operations.
+// LP, the numbers of rows, columns, and elements that were removed during 
+// presolve operations, a flag set if presolve found the model to be unbounded, and
+// the detailed report of the presolve operations.
 type PsSoln struct{
-	ObjVal  float64       // Value of the objective function
-	ConMap  PsResConMap   // Map of string to structs for constraints
-	VarMap  PsResVarMap   // Map of string to structs for variables   
-	RowsDel int           // Number of rows removed during presolve
-	ColsDel int	          // Number of columns removed during presolve
-	ElemDel int           // Number of elements removed during presolve	
+	ObjVal    float64       // Value of the objective function
+	ConMap    PsResConMap   // Map of string to structs for constraints
+	VarMap    PsResVarMap   // Map of string to structs for variables   
+	RowsDel   int           // Number of rows removed during presolve
+	ColsDel   int           // Number of columns removed during presolve
+	ElemDel   int           // Number of elements removed during presolve	
+	Unbounded bool          // True if presolve found the model to be unbounded
+	Ray       map[string]float64  // Primal ray of the original model if unbounded, or nil
+	Scaling   ScaleReport   // Report of the scaling of the reduced model, if any
+	Verify    *VerifyReport // Verification against the original model, or nil if not done
+	RhsRange  PsRangeMap    // RHS ranges of the rows keyed by name, or nil if not calculated
+	ObjRange  PsRangeMap    // Objective ranges of the columns keyed by name, or nil if not calculated
+	Report    PsReport      // Detailed report of the presolve operations
+}
+
+// PsReport contains the results of the presolve operations performed by
+// ReduceMatrix. It lists the statistics of every pass performed in every iteration,
+// the totals of each pass over all iterations, the totals over all passes, and
+// the reason why presolve stopped.
+type PsReport struct {
+	Iterations int             // Number of iterations performed
+	StopReason string          // PsStopNoChange, PsStopMaxIter, PsStopInfeasible, or PsStopError
+	RowsDel    int             // Number of rows removed in all passes
+	ColsDel    int             // Number of columns removed in all passes
+	ElemDel    int             // Number of elements removed in all passes
+	BndsChgd   int             // Number of column bounds tightened in all passes
+	RowsChgd   int             // Number of rows whose coefficients were changed
+	Time       time.Duration   // Time spent in presolve
+	Passes     []PsPassStats   // Statistics of each pass in each iteration
+	PassTotals []PsPassStats   // Statistics of each pass summed over all iterations
+}
+
+// PsPassStats contains the statistics of a single presolve pass included in the
+// PsReport. For the totals of a pass over all iterations, Iter is set to 0.
+type PsPassStats struct {
+	Name       string          // Name of the pass, one of the PsPass constants
+	Iter       int             // Iteration in which the pass was performed
+	RowsDel    int             // Number of rows removed
+	ColsDel    int             // Number of columns removed
+	ElemDel    int             // Number of elements removed
+	BndsChgd   int             // Number of column bounds tightened
+	RowsChgd   int             // Number of rows whose coefficients were changed
+	Time       time.Duration   // Time spent in the pass
+}
+
+// PsInfeasError is the error returned by presolve when it finds the model to be
+// infeasible. Besides the description of the infeasibility, it contains the row
+// and column at which it was found, and the chain of bound derivations which,
+// together with the bounds of the model, prove that the model is infeasible.
+// The steps are listed in the order in which they were derived, so each step
+// only depends on the model and on earlier steps. The error can be retrieved from
+// the one returned by ReduceMatrix with errors.Cause.
+type PsInfeasError struct {
+	Reason  string          // Description of the infeasibility
+	Row     string          // Row which cannot be satisfied, or "" if none
+	Col     string          // Column whose bounds conflict, or "" if none
+	Chain   []PsInfeasStep  // Bound derivations proving the infeasibility
+}
+
+// PsInfeasStep is a single step in the chain of a PsInfeasError. The bound of the
+// column was changed from Prev to Value using the bounds of the row, and the bounds
+// of the other columns of the row, which are either bounds of the model or were
+// derived in earlier steps. Bounds derived by probing have an empty row name.
+// Columns which were fixed and removed from the row that cannot be satisfied are
+// listed with the bound set to "F".
+type PsInfeasStep struct {
+	Col     string          // Name of column whose bound was derived
+	Bound   string          // "L" for lower or "U" for upper bound, "F" if fixed
+	Prev    float64         // Bound before the step
+	Value   float64         // Bound after the step
+	Row     string          // Row from which the bound was derived, or ""
+}
+
+// PsUnbndError is the error returned when presolve or the solver finds the model
+// to be unbounded. It contains the description, the column found by presolve to
+// improve the objective function without limit, if any, and a primal ray of the
+// original model keyed by column name, i.e. a direction in which all constraints
+// remain satisfied and the objective function decreases, or nil if no ray could be
+// recovered. Columns which are not listed in the ray do not change along it. The
+// error can be retrieved from the one returned by the solve functions with
+// errors.Cause.
+type PsUnbndError struct {
+	Reason  string              // Description of the unboundedness
+	Col     string              // Column found unbounded by presolve, or "" if none
+	Ray     map[string]float64  // Primal ray of the original model, or nil
 }
 
 // PsResConMap contains the map of constraints included in PsSoln that is
-// returned to the caller. It contains values calculated by the solver where available,
-// or Status = NA and zero values if constaint was removed during presolve and not
-// processed by the solver.
+// returned to the caller. It contains values calculated by the solver where available.
+// For constraints removed during presolve, postsolve calculates the slack from the
+// values of the variables, and a dual (stored as both Pi and Dual) which keeps the
+// solution dual feasible for LP models. Duals are not meaningful for MILP models.
 type PsResConMap map[string] struct {
 	Status      string    // Reserved for future use, always set to "NA"
 	Type        string    // Inequality type provided as model input
 	Rhs         float64   // RHS provided as model input
 	ScaleFactor float64   // Row scale factor used in original model
-	Pi          float64   // Dual solution (Pi) from solver, 0 if not available
-	Slack       float64   // Slack from solver, 0 if not available
-	Dual        float64   // Dual from solver, 0 if not available
+	Pi          float64   // Dual solution (Pi) from solver or postsolve, 0 if not available
+	Slack       float64   // Slack from solver or postsolve, 0 if not available
+	Dual        float64   // Dual from solver or postsolve, 0 if not available
 }
 
 // PsResVarMap contains the map of variables included in PsSoln that is returned to
-// the caller. It contains values calculated by the solver where available,
-// or Status = NA and zero values if constaint was removed during presolve and not
-// processed by the solver.
+// the caller. It contains values calculated by the solver where available.
+// For variables removed during presolve, postsolve calculates the value, and the
+// reduced cost from the cost and the duals of the constraints of the variable.
+// Reduced costs are not meaningful for MILP models.
 type PsResVarMap map[string] struct {
 	Status      string    // Reserved for future use, always set to "NA"		
 	Value       float64   // Variable value from the solver or postsolve calculation
 	ScaleFactor float64   // Variable scale factor used in original model
-	ReducedCost float64   // Reduced cost from the solver if available, 0 if postsolved
+	ReducedCost float64   // Reduced cost from the solver or postsolve calculation
 }
 
 // psOp is used internally to record the presolve operation performed
@@ -84,6 +182,11 @@ type psOp struct {
 	OpType  string  // Type of reduction operation performed 
 	Col     psCol   // Column deleted from model by this operation (may be nil)
 	Row     psRow   // Row deleted from model by this operation (may be nil)
+	Ref     string  // Name of row or column retained in place of the one deleted
+	Ratio   float64 // Ratio of deleted to retained coefficients, or coefficient of tightened column
+	BndLo   float64 // Lower bound (or RHS) of retained item before the operation
+	BndUp   float64 // Upper bound (or RHS) of retained item before the operation
+	AtBound string  // Bound ("L" or "U") of the row or column that was active, or ""
 }
 
 // psRow is used internally in the list of presolve operations (psOp) to store
@@ -92,17 +195,39 @@ type psRow struct {
 	Name        string   // Row name
 	Type        string   // Row type
 	Rhs         float64  // Row RHS
+	RhsLo       float64  // Row lower bound
+	RhsUp       float64  // Row upper bound
 	ScaleFactor float64  // Row scale factor
 	Coef       []psCoef  // List of coefficients and variables for this row	
 }
 
 // psCoef is part of the psRow structure to store the list of variable names and
 // their coefficients for a particular row (psRow) processed by presolve operations (psOp).
+// It is also part of the psCol structure, where it stores the row names instead.
 type psCoef struct {
-	Name   string   // Variable name
+	Name   string   // Variable (or row) name
 	Value  float64  // Coefficient value
 }
 
+// psBndStep is used internally to record a column bound derived by presolve,
+// together with the derived bounds of other columns that were used, given as
+// indices in the list of derivations (psBndHist).
+type psBndStep struct {
+	Step    PsInfeasStep    // Bound derived
+	Uses    []int           // Indices of earlier derivations used
+}
+
+// psPattern is used internally when searching for parallel rows or columns.
+// It stores the indices of the non-zero elements sorted in ascending order, the
+// coefficients normalized to a unit vector whose first element is positive,
+// the scale used for normalization, and the hash of the normalized pattern.
+type psPattern struct {
+	Index  []int      // Sorted indices of columns (or rows) of non-zero elements
+	Value  []float64  // Normalized coefficient values
+	Scale  float64    // Value by which coefficients were divided to normalize them
+	Hash   uint64     // Hash of the indices and normalized values
+}
+
 // psCol is used internally in the list of presolve operations (psOp) to store
 // data about the column that was removed.
 type psCol struct {
@@ -110,23 +235,70 @@ type psCol struct {
 	Type        string   // Column type
 	BndLo       float64  // Lower bound
 	BndUp       float64  // Upper bound
+	Cost        float64  // Objective function coefficient
 	ScaleFactor float64  // Column scale factor
+	Coef       []psCoef  // List of coefficients and rows for this column
 }
 
 
 // Constants used to determine which presolve operation was performed
 const (
-	psopFreeCol      = "FCS"   // Free and implied free column singleton
+	psopFreeCol      = "FCS"   // Free column singleton
+	psopImplFreeCol  = "IFC"   // Implied free column singleton
+	psopCostFreeCol  = "FCC"   // Free column singleton with objective coefficient
 	psopFixedVar     = "FXV"   // Fixed variable
 	psopRowSingltn   = "RSG"   // Row Singleton
+	psopRowSnglBnd   = "RSB"   // Row singleton inequality converted to bounds
 	psopNbRow        = "NBR"   // Non-binding row
-	psopEmptyCol     = "MTC"   // Empty column
+	psopEmptyCol     = "MTC"   // Empty column, or present only in objective
 	psopEmptyRow     = "MTR"   // Empty row
+	psopDupRow       = "DPR"   // Duplicate (parallel) row
+	psopDupCol       = "DPC"   // Duplicate (parallel) column
+	psopForcingRow   = "FRR"   // Forcing row, all variables fixed at a bound
+	psopRedRow       = "RDR"   // Redundant row
+	psopCoefTight    = "CTG"   // Coefficients and RHS of MILP row tightened
+	psopCliqueExt    = "CLX"   // Clique row extended by columns in conflict with it
+	psopBndTight     = "TBR"   // Column bound tightened by a row
+)
+
+// Reasons for which presolve stopped, returned in PsReport
+const (
+	PsStopNoChange   = "NoChange"    // Last iteration made no change to the model
+	PsStopMaxIter    = "MaxIter"     // Maximum number of iterations was reached
+	PsStopInfeasible = "Infeasible"  // A pass found the model infeasible
+	PsStopError      = "Error"       // A pass failed with another error
+)
+
+// Names of the presolve passes used in PsPassStats
+const (
+	PsPassTightenBounds = "TightenBounds"   // Bound tightening
+	PsPassNbRows        = "NonbindingRows"  // Non-binding rows
+	PsPassProbe         = "Probing"         // Probing on binary variables
+	PsPassCliques       = "Cliques"         // Clique extension and merging
+	PsPassFixedVars     = "FixedVars"       // Fixed variables
+	PsPassDupRows       = "DuplicateRows"   // Duplicate rows
+	PsPassForcingRows   = "ForcingRows"     // Forcing and redundant rows
+	PsPassCoefs         = "Coefficients"    // Coefficient tightening
+	PsPassDupCols       = "DuplicateCols"   // Duplicate columns
+	PsPassRowSingletons = "RowSingletons"   // Row singletons
+	PsPassColSingletons = "ColSingletons"   // Free column singletons
+	PsPassEmptyRows     = "EmptyRows"       // Empty rows
+	PsPassEmptyCols     = "EmptyCols"       // Empty columns
 )
 
+// Relative tolerance used when comparing normalized coefficients of parallel
+// rows or columns.
+const psParTol = 1.0e-9
+
 // Delimiter for sections in PSOP file
 const fileDelim = "#------------------------------------------------------------------------------\n"
 
+// Version of the PSOP file format written by WritePsopFile
+const psopFileVersion = 3
+
+// Placeholder written to the PSOP file for an empty name
+const psopNoName = "-"
+
 const (
 	psVarStatNA   = "NA"    // Var state provided by the solver not available
 	psConStatNA   = "NA"    // Costr state provided by the solver not available
@@ -134,6 +306,22 @@ const (
 
 // Package global variables
 var psOpList []psOp                     // Rows and cols deleted during presolve
+var psOrigSaved bool                    // True if the model before psOpList was saved
+var psRunning   bool                    // True while ReduceMatrix is running
+var psBndRowSaved map[string]psRow      // Latest rows saved by recPsBndTight
+var psUnbounded bool                    // True if presolve found the model unbounded
+var psUnbndCol  string                  // Column found unbounded by presolve
+var psUnbndRay  map[string]float64      // Ray of the reduced model found by presolve
+var psScaleRow  map[string]float64      // Row scale factors applied to the reduced model
+var psScaleCol  map[string]float64      // Column scale factors applied to the reduced model
+var psOrigObj   psRow                   // Objective function before presolve
+var psOrigRows  []psRow                 // Rows before presolve, without coefficients
+var psOrigCols  []psCol                 // Columns before presolve, with coefficients
+var psOrigConst float64                 // Objective function constant before presolve
+var psBndHist   []psBndStep             // Column bounds derived during presolve
+var psBndLoCur  map[string]int          // Latest derivation of lower bound of columns
+var psBndUpCur  map[string]int          // Latest derivation of upper bound of columns
+var psBndRowFix map[string][]int        // Derivations of bounds of columns fixed in rows
 var defaultCplexInput =  "cplexIn.txt"  // MPS file storing reduced matrix
 var defaultCplexOutput = "cplexOut.txt" // File storing cplex solution
 
@@ -166,6 +354,104 @@ func getPstLhs(psRow psRow, psVarMap PsResVarMap, lhs *float64) error {
 
 //==============================================================================
 
+// getPstDual returns the dual value of the constraint specified by rowName from
+// the constraints map passed to the function (psConMap), or zero if the constraint
+// is not in the map. Cplex returns the dual value as Pi and Coin-OR returns it as
+// Dual, while values calculated during postsolve are stored in both.
+func getPstDual(psConMap PsResConMap, rowName string) float64 {
+
+	if mapItem, ok := psConMap[rowName]; ok {
+		if mapItem.Pi != 0 {
+			return mapItem.Pi
+		}
+		return mapItem.Dual
+	}
+
+	return 0
+}
+
+//==============================================================================
+
+// getPstRedCost returns the reduced cost (redCost) of a column removed during 
+// presolve (psCol), calculated from its cost and the duals of the rows in which
+// it had coefficients, taken from the constraints map passed to the function. 
+// Rows which are not in the map have not been restored yet and their dual is
+// taken to be zero; the reduced cost is adjusted when they are restored.
+// In case of failure, it returns an error.
+func getPstRedCost(psCol psCol, psConMap PsResConMap, redCost *float64) error {
+
+	*redCost = psCol.Cost
+
+	for i := 0; i < len(psCol.Coef); i++ {
+		*redCost -= getPstDual(psConMap, psCol.Coef[i].Name) * psCol.Coef[i].Value
+	}
+
+	return nil
+}
+
+//==============================================================================
+
+// addPstRow adds a row removed during presolve (psRow) to the constraints map 
+// (psConMap) with the dual value passed in (dual), and the slack calculated from
+// the variables map (psVarMap). The reduced costs of the variables are not changed.
+// In case of failure, it returns an error.
+func addPstRow(psRow psRow, dual float64, psConMap PsResConMap, psVarMap PsResVarMap) error {
+	var lhs float64  // LHS of the row
+	var err   error  // error returned by secondary functions called
+
+	constrMap := make(PsResConMap)
+	cMapItem  := constrMap[psRow.Name]
+
+	cMapItem.Slack = 0
+	if psRow.Type != "N" {
+		if err = getPstLhs(psRow, psVarMap, &lhs); err != nil {
+			return errors.Wrapf(err, "addPstRow failed for row %s", psRow.Name)
+		}
+		cMapItem.Slack = psRow.Rhs - lhs
+	}
+
+	cMapItem.Type        = psRow.Type
+	cMapItem.Rhs         = psRow.Rhs
+	cMapItem.Pi          = dual
+	cMapItem.Dual        = dual
+	cMapItem.Status      = psConStatNA
+	cMapItem.ScaleFactor = psRow.ScaleFactor
+	psConMap[psRow.Name] = cMapItem
+
+	return nil
+}
+
+//==============================================================================
+
+// adjPstDual adds delta to the dual of the row (psRow) in the constraints map
+// (psConMap), and adjusts the reduced costs of the variables of the row which
+// are in the variables map (psVarMap) so that they remain consistent with it.
+// In case of failure, it returns an error.
+func adjPstDual(psRow psRow, delta float64, psConMap PsResConMap, psVarMap PsResVarMap) error {
+	var dual float64  // new dual value of the row
+
+	cMapItem, ok := psConMap[psRow.Name]
+	if !ok {
+		return errors.Errorf("adjPstDual unable to find row %s", psRow.Name)
+	}
+
+	dual = getPstDual(psConMap, psRow.Name) + delta
+	cMapItem.Pi   = dual
+	cMapItem.Dual = dual
+	psConMap[psRow.Name] = cMapItem
+
+	for j := 0; j < len(psRow.Coef); j++ {
+		if vMapItem, ok := psVarMap[psRow.Coef[j].Name]; ok {
+			vMapItem.ReducedCost -= delta * psRow.Coef[j].Value
+			psVarMap[psRow.Coef[j].Name] = vMapItem
+		}
+	}
+
+	return nil
+}
+
+//==============================================================================
+
 // isMip checks if the problem is considered a MIP according to the solver. It scans
 // the columns, and if it detects any type other than "R" (which is translated
 // to "continuous" for Cplex, it is considered a MIP, and the function returns
@@ -184,6 +470,124 @@ func isMip() bool {
 
 //==============================================================================
 
+// isInteger returns true if the value passed in is within the feasibility
+// tolerance of an integer.
+func isInteger(value float64) bool {
+
+	return math.Abs(value - math.Floor(value + 0.5)) <= Featol
+}
+
+//==============================================================================
+
+// fmtPsFloat returns the shortest string representation of the value passed in
+// from which the value can be restored exactly, for writing to the PSOP file.
+func fmtPsFloat(value float64) string {
+
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+//==============================================================================
+
+// fmtPsName returns the name passed in for writing to the PSOP file, replaced
+// by a placeholder if it is empty. The placeholder is removed by parsePsName.
+func fmtPsName(name string) string {
+
+	if name == "" {
+		return psopNoName
+	}
+	
+	return name
+}
+
+//==============================================================================
+
+// parsePsName returns the name read from the PSOP file, or the empty string if
+// the placeholder written by fmtPsName was read.
+func parsePsName(name string) string {
+
+	if name == psopNoName {
+		return ""
+	}
+	
+	return name
+}
+
+//==============================================================================
+
+// initPsState clears the presolve operations and all other results of presolve
+// kept for postsolve. It is called whenever a new model is loaded, so that the
+// operations of an earlier model are not applied to the new one.
+func initPsState() {
+
+	psOpList     = nil
+	psOrigSaved  = false
+	psUnbounded  = false
+	psUnbndCol   = ""
+	psUnbndRay   = nil
+	psScaleRow   = nil
+	psScaleCol   = nil
+	psOrigObj    = psRow{}
+	psOrigRows   = nil
+	psOrigCols   = nil
+	psOrigConst  = 0
+	psBndHist    = nil
+	psBndLoCur   = nil
+	psBndUpCur   = nil
+	psBndRowFix  = nil
+	psImplList   = nil
+	psCliqueList = nil
+	psBndRowSaved = nil
+}
+
+//==============================================================================
+
+// savePsOrig saves the objective function, its constant, and the rows of the
+// model before any presolve operation is performed, so that the solution can be
+// completed by PostSolve, possibly after being written to and read from the PSOP
+// file. The coefficients of rows other than the objective function are not saved.
+// In case of failure, function returns an error.
+func savePsOrig() error {
+	var origRow psRow  // row before presolve
+
+	psOrigObj   = psRow{}
+	psOrigRows  = nil
+	psOrigCols  = nil
+	psOrigConst = objRowConst
+	psOrigSaved = true
+
+	for i := 0; i < len(Rows); i++ {
+		if err := translateRow(Rows[i], &origRow); err != nil {
+			return errors.Wrap(err, "savePsOrig failed")
+		}
+
+		if i == ObjRow {
+			psOrigObj = origRow
+		}
+
+		origRow.Coef = nil
+		psOrigRows   = append(psOrigRows, origRow)
+	}
+
+	// Save the columns with their coefficients, so that solutions can be checked
+	// against the original model.
+	for j := 0; j < len(Cols); j++ {
+		origCol := psCol{Name: Cols[j].Name, Type: Cols[j].Type, BndLo: Cols[j].BndLo,
+			BndUp: Cols[j].BndUp, ScaleFactor: Cols[j].ScaleFactor}
+
+		for k := 0; k < len(Cols[j].HasElems); k++ {
+			iel := Cols[j].HasElems[k]
+			origCol.Coef = append(origCol.Coef, psCoef{Name: Rows[Elems[iel].InRow].Name,
+				Value: Elems[iel].Value})
+		}
+
+		psOrigCols = append(psOrigCols, origCol)
+	}
+
+	return nil
+}
+
+//==============================================================================
+
 // translateRow translates a single constraint (oldRow) to the psRow format 
 // and returns it as newRow in the argument list.
 // In case of failure, function returns an error.
@@ -199,6 +603,8 @@ func translateRow(oldRow InputRow, newRow *psRow) error {
 	
 	newRow.Name        = oldRow.Name
 	newRow.Type        = oldRow.Type
+	newRow.RhsLo       = oldRow.RHSlo
+	newRow.RhsUp       = oldRow.RHSup
 	newRow.ScaleFactor = oldRow.ScaleFactor
 
 	switch oldRow.Type {
@@ -296,6 +702,7 @@ func addConMapItem(psConMap PsResConMap, psRow psRow) error {
 // In case of failure, function returns an error.
 func updatePsList(opType string, rowIndex int, colIndex int) error {
 	var psItem psOp   // item associated with a post-solve operation list
+	var coef  psCoef  // row name and coefficient of column being deleted
 	var err   error   // error returned by called functions
 	
 	// Transfer all variables "as is" from Cols data structures, if a column
@@ -309,6 +716,26 @@ func updatePsList(opType string, rowIndex int, colIndex int) error {
 		psItem.Col.BndLo       = Cols[colIndex].BndLo
 		psItem.Col.BndUp       = Cols[colIndex].BndUp
 		psItem.Col.ScaleFactor = Cols[colIndex].ScaleFactor		
+
+		for j := 0; j < len(Cols[colIndex].HasElems); j++ {
+			coef.Name  = Rows[Elems[Cols[colIndex].HasElems[j]].InRow].Name
+			coef.Value = Elems[Cols[colIndex].HasElems[j]].Value
+
+			if Elems[Cols[colIndex].HasElems[j]].InRow == ObjRow {
+				psItem.Col.Cost = coef.Value
+			} else {
+				psItem.Col.Coef = append(psItem.Col.Coef, coef)
+			}
+		}
+
+		// The RHS of the rows of a fixed column depend on the derivation of
+		// its bounds, which is needed to explain an infeasibility.
+		if (opType == psopFixedVar || opType == psopRowSingltn) && psBndRowFix != nil {
+			for j := 0; j < len(psItem.Col.Coef); j++ {
+				psBndRowFix[psItem.Col.Coef[j].Name] = append(psBndRowFix[psItem.Col.Coef[j].Name],
+					colBndRoots(psItem.Col.Name)...)
+			}
+		}
 	} // End if a column was deleted	
 	
 	// If a row was deleted, translate it to the new format and add it to
@@ -327,1118 +754,3971 @@ func updatePsList(opType string, rowIndex int, colIndex int) error {
 	return nil
 }
 
-
 //==============================================================================
 
-// postSolve updates the constraint map (pscMap) and variable map (solvedVarMap)
-// referenced in the argument list with information stored in the package global
-// list of presolve operations (psOpList). Effectively, this function merges the
-// results calculated by the solver with results obtained by "reversing" the presolve
-// operations. In case of failure, function returns an error.
-func postSolve(pscMap PsResConMap, solvedVarMap PsResVarMap) error {
-	var rhs   float64  // RHS of row being processed
-	var lhs   float64  // LHS of row being processed
-	var coef  float64  // holder for value of coefficient being processed
-	var curVar psCoef  // holder for variable structure being processed
-		
-	for i := len(psOpList) - 1; i >= 0; i-- {
-	
-		switch psOpList[i].OpType {
-
-		// Operations recorded so they could be printed, but which don't need
-		// any post-solve steps and can be ignored
-		case psopEmptyRow, psopNbRow:
-			continue
-						
-		// Fixed Variable ------------------------------------------------------	
-		case psopFixedVar:
-
-			// Calculate variable value and add it to solved variables map.
-			// There is no row information to transfer for this item.
-			varbMap := make(PsResVarMap)
-			vMapItem := varbMap[psOpList[i].Col.Name]
-			vMapItem.Value       = psOpList[i].Col.BndLo
-			vMapItem.ReducedCost = 0
-			vMapItem.Status      = psVarStatNA
-			vMapItem.ScaleFactor = psOpList[i].Col.ScaleFactor
-			solvedVarMap[psOpList[i].Col.Name] = vMapItem
+// setPsRef completes the most recent item in the list of presolve operations by
+// recording the name of the row or column retained in place of the one removed
+// (refName), the ratio of the removed to the retained coefficients (ratio), and
+// the bounds of the retained item before the operation (bndLo, bndUp).
+// In case of failure, function returns an error.
+func setPsRef(refName string, ratio float64, bndLo float64, bndUp float64) error {
+	var last int  // index of last item in the presolve operations list
 
-		// Empty Column ------------------------------------------------------	
-		case psopEmptyCol:
+	last = len(psOpList) - 1
+	if last < 0 {
+		return errors.New("setPsRef found empty presolve operations list")
+	}
 
-			// Set the value to zero, since at removal it was in range from 0
-			// to infinity in either positive or negative direction.
-			varbMap := make(PsResVarMap)
-			vMapItem := varbMap[psOpList[i].Col.Name]
-			vMapItem.Value       = 0
-			vMapItem.ReducedCost = 0
-			vMapItem.Status      = psVarStatNA
-			vMapItem.ScaleFactor = psOpList[i].Col.ScaleFactor
-			solvedVarMap[psOpList[i].Col.Name] = vMapItem
-				
-		// Free Column Singleton -----------------------------------------------	
-		case psopFreeCol:	
+	psOpList[last].Ref   = refName
+	psOpList[last].Ratio = ratio
+	psOpList[last].BndLo = bndLo
+	psOpList[last].BndUp = bndUp
 
-			// First get the RHS and coefficient value
-			rhs = psOpList[i].Row.Rhs
-			lhs = 0
-			coef = 0
-			for j := 0; j < len(psOpList[i].Row.Coef); j++ {
-				curVar.Name = psOpList[i].Row.Coef[j].Name
-				if curVar.Name == psOpList[i].Col.Name {
-					// If this is the varb we are looking for, get coef. for this row
-					coef = psOpList[i].Row.Coef[j].Value					
-				} else {
-					// If different varb, sum up its value times coef (lhs) for this row
-					if psVar, ok := solvedVarMap[curVar.Name]; !ok {
-						return errors.Errorf("postSolve unable to find value for %s", curVar.Name)
-					} else {
-						lhs += psVar.Value * psOpList[i].Row.Coef[j].Value
-					} // End else increment lhs value
-				} // End else not variable we need to solve
-			} // End for all variables in row
-			
-			// Calculate variable value and add it to solved variables map
-			varbMap             := make(PsResVarMap)
-			vMapItem            := varbMap[psOpList[i].Col.Name]
-			vMapItem.Value       = (rhs - lhs) / coef
-			vMapItem.ReducedCost = 0
-			vMapItem.Status      = psVarStatNA
-			vMapItem.ScaleFactor = psOpList[i].Col.ScaleFactor
-			solvedVarMap[psOpList[i].Col.Name] = vMapItem
+	return nil
+}
 
-			// Get deleted row details and add it to solved constraints map
-			constrMap      := make(PsResConMap)
-			cMapItem       := constrMap[psOpList[i].Row.Name]
-			cMapItem.Type        = psOpList[i].Row.Type
-			cMapItem.Rhs         = psOpList[i].Row.Rhs
-			cMapItem.Dual        = 0
-			cMapItem.Slack       = 0
-			cMapItem.Status      = psConStatNA
-			cMapItem.ScaleFactor = psOpList[i].Row.ScaleFactor
-			pscMap[psOpList[i].Row.Name] = cMapItem
-			
+//==============================================================================
 
-		// Row Singleton -------------------------------------------------------	
-		case psopRowSingltn:
+// setPsAtBound completes the most recent item in the list of presolve operations by
+// recording which bound ("L" or "U") of the row or column was active (bound).
+// In case of failure, function returns an error.
+func setPsAtBound(bound string) error {
+	var last int  // index of last item in the presolve operations list
 
-			rhs = psOpList[i].Row.Rhs
-			coef = 0
-			for j := 0; j < len(psOpList[i].Row.Coef); j++ {
-				if psOpList[i].Row.Coef[j].Name == psOpList[i].Col.Name {
-					coef = psOpList[i].Row.Coef[j].Value
-					break
-				} 
-			}
-			
-			if coef == 0 {
-				return errors.New("postSolve unable to find coefficient")
-			}
-			
-			// Calculate variable value and add it to solved variables map
-			varbMap             := make(PsResVarMap)
-			vMapItem            := varbMap[psOpList[i].Col.Name]
-			vMapItem.Value       = rhs / coef
-			vMapItem.ReducedCost = 0
-			vMapItem.Status      = psVarStatNA
-			vMapItem.ScaleFactor = psOpList[i].Col.ScaleFactor
-			solvedVarMap[psOpList[i].Col.Name] = vMapItem
+	last = len(psOpList) - 1
+	if last < 0 {
+		return errors.New("setPsAtBound found empty presolve operations list")
+	}
 
-			// Get deleted row details and add it to solved constraints map
-			constrMap      := make(PsResConMap)
-			cMapItem       := constrMap[psOpList[i].Row.Name]
-			cMapItem.Type        = psOpList[i].Row.Type
-			cMapItem.Rhs         = psOpList[i].Row.Rhs
-			cMapItem.Dual        = 0
-			cMapItem.Slack       = 0
-			cMapItem.Status      = psConStatNA
-			cMapItem.ScaleFactor = psOpList[i].Row.ScaleFactor
-			pscMap[psOpList[i].Row.Name] = cMapItem
-					
+	psOpList[last].AtBound = bound
 
-		// Something unknown ---------------------------------------------------	
-		default:
-			return errors.Errorf("Unexpected operation %s in postSolve", psOpList[i].OpType)
-		} // End switch on operation type
-		
-	} // End for processing psOpList
-	
 	return nil
 }
 
 //==============================================================================
-// ROW REDUCTION OPERATIONS
-//==============================================================================
-
-// swapRows switches the rows specified by source and destination indices 
-// (srcIndex, destIndex) in Rows list and updates all cross-references.
-// In case of failure, it returns an error.
-func swapRows(srcIndex int, destIndex int) error {
-	var tempRow InputRow  // holder for row being moved
-	var index        int  // holder for index of item being processed
 
-	// Return error if indices are out of range
+// recPsBndTight adds to the list of presolve operations that the bound (bound) of
+// the column specified by colIndex was tightened by the row specified by rowIndex,
+// in which the column has the coefficient passed in (coef), so that postsolve can
+// transfer the reduced cost of the column to the row if the bound is active. The
+// row is saved as it is when the bound is tightened, unless it is unchanged since
+// it last tightened a bound, in which case only its name and type are kept.
+// Nothing is recorded unless ReduceMatrix is running.
+func recPsBndTight(rowIndex int, colIndex int, coef float64, bound string) {
+	var psItem psOp   // item added to the presolve operations list
+	var row    psRow  // row as it is now
+
+	if !psRunning {
+		return
+	}
 
-	if srcIndex < 0 || srcIndex >= len(Rows) {
-		return errors.Errorf("Source index %d out of range in swapRows", srcIndex)
+	psItem.OpType      = psopBndTight
+	psItem.Ratio       = coef
+	psItem.AtBound     = bound
+	psItem.Col.Name    = Cols[colIndex].Name
+	psItem.Col.Type    = Cols[colIndex].Type
+	psItem.Col.BndLo   = Cols[colIndex].BndLo
+	psItem.Col.BndUp   = Cols[colIndex].BndUp
+	psItem.Col.ScaleFactor = Cols[colIndex].ScaleFactor
+	psItem.Row.Name    = Rows[rowIndex].Name
+	psItem.Row.Type    = Rows[rowIndex].Type
+
+	_ = translateRow(Rows[rowIndex], &row)
+	if saved, ok := psBndRowSaved[row.Name]; !ok || !samePsRow(row, saved) {
+		psItem.Row = row
+		psBndRowSaved[row.Name] = row
 	}
 
-	if destIndex < 0 || destIndex >= len(Rows) {
-		return errors.Errorf("Dest. index %d out of range in swapRows", destIndex)
-	}
+	psOpList = append(psOpList, psItem)
+}
 
-	// Swap the references to the two rows in the elements lists.
+//==============================================================================
 
-	for i := 0; i < len(Rows[srcIndex].HasElems); i++ {
-		index = Rows[srcIndex].HasElems[i]
-		Elems[index].InRow = destIndex
+// samePsRow returns true if the two rows passed in (row1, row2) have the same
+// type, RHS, scale factor, and coefficients in the same order.
+func samePsRow(row1 psRow, row2 psRow) bool {
+
+	if row1.Type != row2.Type || row1.Rhs != row2.Rhs || row1.RhsLo != row2.RhsLo ||
+		row1.RhsUp != row2.RhsUp || row1.ScaleFactor != row2.ScaleFactor ||
+		len(row1.Coef) != len(row2.Coef) {
+		return false
 	}
 
-	for i := 0; i < len(Rows[destIndex].HasElems); i++ {
-		index = Rows[destIndex].HasElems[i]
-		Elems[index].InRow = srcIndex
+	for j := 0; j < len(row1.Coef); j++ {
+		if row1.Coef[j] != row2.Coef[j] {
+			return false
+		}
 	}
 
-	// Swap the two rows.
+	return true
+}
 
-	tempRow         = Rows[destIndex]
-	Rows[destIndex] = Rows[srcIndex]
-	Rows[srcIndex]  = tempRow
+//==============================================================================
 
-	return nil
-}
+// Error returns the description of the infeasibility, so that
+// PsInfeasError satisfies the error interface.
+func (e *PsInfeasError) Error() string {
 
+	return e.Reason
+}
 
 //==============================================================================
 
-// DelRow deletes the row specified by index srcRow, and updates all cross references.
-// If srcRow is not already the last row in the list, the row is swapped with the
-// last row and is deleted from the end of the list.
-// In case of failure, it returns an error.
-func DelRow(srcRow int) error {
-	var iCurElem       int   // index of current element
-	var iLastElem      int   // index of last element in list
-	var lastRow        int   // index of last row in list
-	var index          int   // holder for index being processed
-	var elemList     []int   // list of element associated with item
-	var newElemList  []int   // new element list created after items deleted
-	var tempElem InputElem   // temporary holder for element
-	var err          error   // error received from called functions
+// Error returns the description of the unboundedness, so that PsUnbndError
+// satisfies the error interface.
+func (e *PsUnbndError) Error() string {
 
-	lastRow = len(Rows) - 1
-	
-	// Check that index of row to be deleted is valid.
-	if srcRow < 0 || srcRow > lastRow {
-		return errors.Errorf("Row index %d out of range", srcRow)
-	}
+	return e.Reason
+}
 
-	// If row to be deleted is not the last row, swap rows to put it at end of list.
-	if srcRow != lastRow {
-		if err = swapRows(srcRow, lastRow); err != nil {
-			return errors.Wrap(err, "Row swap failed")
-		}			
+//==============================================================================
+
+// recBndStep records the derivation of the bound ("L" or "U") of the column
+// specified by colIndex, which was changed from prev to its current value using
+// the row specified by rowIndex, or by probing or clique merging if rowIndex is
+// negative. The side of the row used ("L" if its lower bound, "U" if its upper
+// bound, or "" if the column is the only one in the row) determines which bounds
+// of the other columns were used in the derivation.
+func recBndStep(colIndex int, bound string, prev float64, rowIndex int, rowSide string) {
+	var item   psBndStep  // derivation being recorded
+	var index        int  // index of element being processed
+	var useLo       bool  // true if lower bound of other column was used
+
+	if psBndLoCur == nil || psBndUpCur == nil {
+		psBndLoCur  = make(map[string]int)
+		psBndUpCur  = make(map[string]int)
+		psBndRowFix = make(map[string][]int)
 	}
 
-	// Step through the list of elements in the row to be deleted and migrate
-	// them to the end of the elements list by swapping with those that remain.
-	// Use temporary elemList to keep track of all elements that need to be
-	// processed because the HasElems list associated with the current row may be changing.
-	
-	iLastElem = len(Elems) - 1
-	elemList  = Rows[lastRow].HasElems
-	
-	for i := 0; i < len(elemList); i++ {
+	item.Step = PsInfeasStep{Col: Cols[colIndex].Name, Bound: bound, Prev: prev,
+		Value: Cols[colIndex].BndLo}
+	if bound == "U" {
+		item.Step.Value = Cols[colIndex].BndUp
+	}
 
-		iCurElem = elemList[i]
+	if rowIndex >= 0 {
+		item.Step.Row = Rows[rowIndex].Name
+		item.Uses     = append(item.Uses, psBndRowFix[Rows[rowIndex].Name]...)
 
-		// Remove element to be deleted from the column where it occurs.
-		index = Elems[iCurElem].InCol
-		newElemList = nil
-		for j := 0; j < len(Cols[index].HasElems); j++ {
-			if Cols[index].HasElems[j] != iCurElem {
-				newElemList = append(newElemList, Cols[index].HasElems[j])
-			}
-		}
-		Cols[index].HasElems = newElemList
-		
-		// Find	the row location of the former last element and update reference.
-		index = Elems[iLastElem].InRow
-		for j := 0; j < len(Rows[index].HasElems); j++ {
-			if Rows[index].HasElems[j] == iLastElem {
-				Rows[index].HasElems[j] = iCurElem
-				break
+		for i := 0; rowSide != "" && i < len(Rows[rowIndex].HasElems); i++ {
+			index = Rows[rowIndex].HasElems[i]
+			if Elems[index].InCol == colIndex {
+				continue
 			}
-		}
 
-		// Find the column location of the former last element and update reference.
-		index = Elems[iLastElem].InCol
-		for j := 0; j < len(Cols[index].HasElems); j++ {
-			if Cols[index].HasElems[j] == iLastElem {
-				Cols[index].HasElems[j] = iCurElem
-				break
+			// The upper bound of the row is used with the minimum activity of the
+			// other columns, and the lower bound with the maximum activity.
+			useLo = (Elems[index].Value > 0) == (rowSide == "U")
+			if useLo {
+				if k, ok := psBndLoCur[Cols[Elems[index].InCol].Name]; ok {
+					item.Uses = append(item.Uses, k)
+				}
+			} else {
+				if k, ok := psBndUpCur[Cols[Elems[index].InCol].Name]; ok {
+					item.Uses = append(item.Uses, k)
+				}
 			}
-		}
-		
-		// Swap the elements and update index of next available slot.
-		tempElem         = Elems[iLastElem]
-		Elems[iLastElem] = Elems[iCurElem]
-		Elems[iCurElem]  = tempElem
-		iLastElem--	
-		
-	} // End for all elements of row being deleted.
+		} // End for all elements in row
+	} // End if derived from a row
 
-	// Reslice the rows and elements lists.
-	Elems = append(Elems[:iLastElem + 1])
-	Rows  = append(Rows[:len(Rows) - 1])
-	
-	return nil
+	psBndHist = append(psBndHist, item)
+
+	if bound == "L" {
+		psBndLoCur[Cols[colIndex].Name] = len(psBndHist) - 1
+	} else {
+		psBndUpCur[Cols[colIndex].Name] = len(psBndHist) - 1
+	}
 }
 
 //==============================================================================
 
-// DelCol deletes the column specified by srcCol and updates all cross references.
-// If the column to be deleted is not already the last column in the list, it is
-// swapped with the last column and is deleted from the end of the list.
-// In case of failure, it returns an error.
-func DelCol(srcCol int) error {
-	var err          error  // error string returned from other functions
-	var lastCol        int  // index of last column in the global list
-	var iCurElem       int  // index of current element being processed
-	var iLastElem      int  // index of last element in global list
-	var index          int  // general variable for storing indices as needed
-	var elemList     []int  // list of elements being processed
-	var newElemList  []int  // new list excluding elements that were deleted
-	var tempElem InputElem  // placeholder for swapping items in element list
+// colBndRoots returns the indices of the latest derivations of the lower and
+// upper bounds of the column specified by colName, if they were derived.
+func colBndRoots(colName string) []int {
+	var roots []int  // indices of derivations
 
+	if k, ok := psBndLoCur[colName]; ok {
+		roots = append(roots, k)
+	}
+	if k, ok := psBndUpCur[colName]; ok {
+		roots = append(roots, k)
+	}
 
-	lastCol = len(Cols) - 1
+	return roots
+}
 
-	// Exit if index we received is out of range.
-	if srcCol < 0 || srcCol > lastCol {
-		return errors.Errorf("Column index %d out of range", srcCol)
+//==============================================================================
+
+// psInfeasible returns the error describing an infeasibility (reason) found at
+// the row and column specified by rowName and colName, with the chain of all
+// derivations on which the derivations specified by roots depend.
+func psInfeasible(reason string, rowName string, colName string, roots []int) *PsInfeasError {
+	var inChain map[int]bool  // derivations included in chain
+	var toVisit        []int  // derivations still to be visited
+	var index          []int  // sorted indices of derivations in chain
+
+	infErr  := &PsInfeasError{Reason: reason, Row: rowName, Col: colName}
+	inChain  = make(map[int]bool)
+	toVisit  = append(toVisit, roots...)
+
+	for len(toVisit) > 0 {
+		k := toVisit[len(toVisit) - 1]
+		toVisit = toVisit[:len(toVisit) - 1]
+
+		if inChain[k] || k < 0 || k >= len(psBndHist) {
+			continue
+		}
+
+		inChain[k] = true
+		toVisit    = append(toVisit, psBndHist[k].Uses...)
+	} // End while derivations to visit
+
+	for k := range inChain {
+		index = append(index, k)
 	}
+	sort.Ints(index)
 
-	// If column to be deleted is not already the last one, swap with last column.
-	if srcCol != lastCol {
-		if err = swapCols(srcCol, lastCol); err != nil {
-			return errors.Wrap(err, "Column swap failed")			
-		}	
+	for i := 0; i < len(index); i++ {
+		infErr.Chain = append(infErr.Chain, psBndHist[index[i]].Step)
 	}
-	
-	// Step through the list of elements in the column to be deleted and migrate
-	// them to the end of the elements list by swapping with those that remain.
-	// Use temporary elemList to keep track of all elements that need to be
-	// processed because the Elem list associated with the current column may be changing.
-	
-	iLastElem = len(Elems) - 1
-	elemList  = Cols[lastCol].HasElems
 
-	for i := 0; i < len(elemList); i++ {
-	
-		iCurElem = elemList[i]
-				
-		// Remove element to be deleted from row where it occurs.
-		index       = Elems[iCurElem].InRow
-		newElemList = nil
-		for j := 0; j < len(Rows[index].HasElems); j++ {
-			if Rows[index].HasElems[j] != iCurElem {
-				newElemList = append(newElemList, Rows[index].HasElems[j])
-			}	
-		}
-		Rows[index].HasElems = newElemList
+	return infErr
+}
 
-		// Find	the row location of the former last element and update reference.
-		index = Elems[iLastElem].InRow
-		for j := 0; j < len(Rows[index].HasElems); j++ {
-			if Rows[index].HasElems[j] == iLastElem {
-				Rows[index].HasElems[j] = iCurElem
-				break
-			}
-		}
+//==============================================================================
 
-		// Find the column location of the former last element and update reference.
-		index = Elems[iLastElem].InCol
-		for j := 0; j < len(Cols[index].HasElems); j++ {
-			if Cols[index].HasElems[j] == iLastElem {
-				Cols[index].HasElems[j] = iCurElem
-				break
-			}
-		}
-		
-		// Swap the elements and update index of next available slot.
-		tempElem          = Elems[iLastElem]
-		Elems[iLastElem] = Elems[iCurElem]
-		Elems[iCurElem]  = tempElem
+// rowBndRoots returns the indices of the latest derivations of the bounds of all
+// columns in the row specified by rowIndex.
+func rowBndRoots(rowIndex int) []int {
+	var roots []int  // indices of derivations
 
-		iLastElem--				
+	for i := 0; i < len(Rows[rowIndex].HasElems); i++ {
+		roots = append(roots, colBndRoots(Cols[Elems[Rows[rowIndex].HasElems[i]].InCol].Name)...)
 	}
 
-	// Reslice the rows and elements lists.
-	Elems = append(Elems[:iLastElem + 1])
-	Cols  = append(Cols[:len(Cols) - 1])
-	
-	return nil
+	return roots
 }
 
 //==============================================================================
 
-// delTaggedRows finds rows tagged for deletion, moves them to end of list by 
-// swapping with still-active rows, deletes all tagged rows, and updates 
-// cross-references. Function passes back the number of rows deleted in the numDltd
-// variable.
-// In case of failure, function returns an error.
-func delTaggedRows(numDltd *int) error {
-	var err error    // error received from called functions
+// emptyRowInfeasible returns the error describing the infeasibility of the empty
+// row specified by rowIndex, whose bounds exclude zero. The columns that were
+// fixed and removed from the row are found in the list of presolve operations,
+// and are added to the chain after the derivations of their bounds.
+func emptyRowInfeasible(rowIndex int) *PsInfeasError {
+	var roots       []int  // derivations of bounds of fixed columns
+	var fixed     []psCol  // columns fixed and removed from the row
+	var fixedRow []string  // rows which fixed the columns, or "" if none
 
-	// Delete any rows tagged for deletion by looking from the end of the list.
-	// Swapping of rows to put them at the end is done by the deletion function.
-	for i := len(Rows) - 1; i >= 0; i-- {
+	rowName := Rows[rowIndex].Name
 
-		if Rows[i].State == stateDelete {
+	for i := 0; i < len(psOpList); i++ {
+		if psOpList[i].OpType != psopFixedVar && psOpList[i].OpType != psopRowSingltn {
+			continue
+		}
 
-			if err = DelRow(i); err != nil {
-				return errors.Wrapf(err, "Failed to delete row %d", i)
-			} // End if row deletion failed
+		for j := 0; j < len(psOpList[i].Col.Coef); j++ {
+			if psOpList[i].Col.Coef[j].Name == rowName {
+				roots    = append(roots, colBndRoots(psOpList[i].Col.Name)...)
+				fixed    = append(fixed, psOpList[i].Col)
+				fixedRow = append(fixedRow, psOpList[i].Row.Name)
+				break
+			}
+		}
+	} // End for all presolve operations
 
-			*numDltd = *numDltd + 1
+	infErr := psInfeasible(fmt.Sprintf("delEmptyRows infeasible, bounds of empty row %s exclude zero",
+		rowName), rowName, "", roots)
 
-		} // End if found row to delete
-	} // End for all rows in list
+	for i := 0; i < len(fixed); i++ {
+		infErr.Chain = append(infErr.Chain, PsInfeasStep{Col: fixed[i].Name, Bound: "F",
+			Prev: fixed[i].BndLo, Value: fixed[i].BndLo, Row: fixedRow[i]})
+	}
 
-	return nil
+	return infErr
 }
 
 //==============================================================================
 
-// delNbRows searches the Rows list for any non-binding rows that are still
-// in the active state and deletes them. It passes back the number of rows deleted
-// in the numDltd variable.
+// rowTypeFromBounds returns the row type ("E", "G", "L", "R", or "N") implied by
+// the lower and upper bounds (lo, up) of a constraint.
+func rowTypeFromBounds(lo float64, up float64) string {
+
+	switch {
+	case lo <= -Plinfy && up >= Plinfy:
+		return "N"
+	case lo <= -Plinfy:
+		return "L"
+	case up >= Plinfy:
+		return "G"
+	case lo == up:
+		return "E"
+	}
+
+	return "R"
+}
+
+//==============================================================================
+
+// makePattern builds the normalized pattern (pattern) from the list of indices
+// (index) and coefficients (value) of the non-zero elements of a row or column.
+// The coefficients are divided by the scale passed in, or by the length of the 
+// coefficient vector if scale is 0, and the sign is chosen so that the first
+// normalized coefficient is positive. The hash is built from the indices and the
+// normalized values rounded to a coarse grid, so parallel items produce the same
+// hash and only the few items sharing a hash need to be compared in detail.
 // In case of failure, function returns an error.
-func delNbRows(numDltd *int) error {
-	var err error  // error received from called functions
-		
-	log(pINFO, "Looking for non-binding rows...\n")
+func makePattern(index []int, value []float64, scale float64, pattern *psPattern) error {
+	var order    []int  // order in which the elements are to be stored
+	var hash    uint64  // hash value being built
+	var grid     int64  // normalized value rounded to the hashing grid
+
+	if len(index) != len(value) {
+		return errors.Errorf("makePattern received %d indices and %d values", 
+			len(index), len(value))
+	}
+
+	order = make([]int, len(index))
+	for i := 0; i < len(order); i++ {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool { return index[order[a]] < index[order[b]] })
 	
-	*numDltd = 0
+	pattern.Index = make([]int, len(index))
+	pattern.Value = make([]float64, len(index))
+	pattern.Scale = 0
+	pattern.Hash  = 0
 
-	// Skip over rows if they are not active.	
-	for i := 0; i < len(Rows); i++ {
+	if len(index) == 0 {
+		return nil
+	}
 
-		if Rows[i].State != stateActive {
-			continue
+	if scale == 0 {
+		for i := 0; i < len(value); i++ {
+			scale += value[i] * value[i]
 		}
-		
-		if Rows[i].Type == "N" {
-			Rows[i].State = stateDelete	
-			_ = updatePsList(psopNbRow, i, -1)
-		}	
-	} // End for looping over all rows	
+		scale = math.Sqrt(scale)
+	}
 
-	if err = delTaggedRows(numDltd); err != nil {
-		return errors.Wrap(err, "delNbRows failed")
+	if scale == 0 {
+		return errors.New("makePattern received only zero coefficients")
 	}
-	
-	if *numDltd != 0 {
-		log(pINFO, "Deleted %d non-binding rows.\n", *numDltd)
+
+	// Make the first coefficient (in sorted order) positive.
+	if value[order[0]] < 0 {
+		scale = -scale
 	}
+	pattern.Scale = scale
+
+	// Build the hash using the FNV-1a algorithm on the indices and rounded values.
+	hash = 14695981039346656037	
+	for i := 0; i < len(order); i++ {
+		pattern.Index[i] = index[order[i]]
+		pattern.Value[i] = value[order[i]] / scale
+		grid = int64(math.Floor(pattern.Value[i] * 1.0e6 + 0.5))
+
+		hash ^= uint64(pattern.Index[i])
+		hash *= 1099511628211
+		hash ^= uint64(grid)
+		hash *= 1099511628211		
+	}
+	pattern.Hash = hash
 	
 	return nil
 }
 
 //==============================================================================
 
-// delEmptyRows searches the Rows list for any empty rows that are still
-// in the active state and deletes them. It passes back the number of rows deleted
-// in the numDltd variable.
-// In case of failure, function returns an error.
-func delEmptyRows(numDltd *int) error {
-	var err error  // error received from called functions
-		
-	log(pINFO, "Looking for empty rows...\n")
-
-	*numDltd = 0
-	
-	for i := 0; i < len(Rows); i++ {
-
-		// Skip over any rows that are not still active or that are not empty	
-		if  len(Rows[i].HasElems) > 0 || Rows[i].State != stateActive {
-			continue
+// emptyColValue returns the value of a column which does not appear in any
+// constraint, given its bounds (bndLo, bndUp), cost, and type (colType). The column
+// is at its lower bound if the cost is positive, at its upper bound if the cost
+// is negative, and at the value within its bounds closest to zero otherwise.
+// The second value returned is false if the bound required is infinite, so the
+// objective function is unbounded.
+func emptyColValue(bndLo float64, bndUp float64, cost float64, colType string) (float64, bool) {
+	var value float64  // value of the column
+
+	switch {
+	case cost > 0:
+		if bndLo <= -Plinfy {
+			return 0, false
 		}
+		value = bndLo
 
-		// Lower bounds may not be correct.	
-		if Rows[i].RHSlo == -Plinfy && Rows[i].RHSup != 0 {
-			log(pWARN, "WARNING: Empty row %s has bounds %f to %f.\n",
-				Rows[i].Name, Rows[i].RHSlo, Rows[i].RHSup)
-		}	
-
-		// Upper bounds may not be correct.	
-		if Rows[i].RHSlo != 0 && Rows[i].RHSup == Plinfy {
-			log(pWARN, "WARNING: Empty row %s has bounds %f to %f.\n",
-				Rows[i].Name, Rows[i].RHSlo, Rows[i].RHSup)
-		}	
+	case cost < 0:
+		if bndUp >= Plinfy {
+			return 0, false
+		}
+		value = bndUp
 
-		Rows[i].State = stateDelete
-		_ = updatePsList(psopEmptyRow, i, -1)
-		log(pDEB, "  Row %s removed.\n", Rows[i].Name)
-	
-	} // End for all rows
+	default:
+		value = math.Min(math.Max(0, bndLo), bndUp)
+	} // End switch on sign of cost
 
-	if err = delTaggedRows(numDltd); err != nil {
-		return errors.Wrap(err, "delEmptyRows failed")
-	}
-	
-	if *numDltd != 0 {
-		log(pINFO, "Deleted %d empty rows.\n", *numDltd)		
+	if colType == "I" {
+		if value == bndUp {
+			value = snap(value, "U")
+		} else {
+			value = snap(value, "L")
+		}
 	}
 
-	return nil
+	return value, true
 }
 
 //==============================================================================
 
-// delEmptyCols searches the Cols list for any empty columns that are still
-// in the active state and deletes them. It passes back the number of columns deleted
-// in the numDltd variable.
+// calcRowActivity calculates the minimum (minAct) and maximum (maxAct) activity
+// of the row specified by rowIndex from the bounds of the variables in the row.
+// Contributions of infinite bounds are not added to the activities, but are counted
+// in minInf and maxInf instead, so an activity is only finite if its count is zero.
 // In case of failure, function returns an error.
-func delEmptyCols(numDltd *int) error {
-	var err error  // error received from called functions
-		
-	log(pINFO, "Looking for empty columns...\n")
+func calcRowActivity(rowIndex int, minAct *float64, maxAct *float64, minInf *int, maxInf *int) error {
+	var index      int  // index of element being processed
+	var coef   float64  // coefficient of element being processed
+	var bndLo  float64  // lower bound of variable
+	var bndUp  float64  // upper bound of variable
+
+	if rowIndex < 0 || rowIndex >= len(Rows) {
+		return errors.Errorf("Row index %d out of range in calcRowActivity", rowIndex)
+	}
 
-	*numDltd = 0
-	
-	for i := 0; i < len(Cols); i++ {
+	*minAct = 0
+	*maxAct = 0
+	*minInf = 0
+	*maxInf = 0
+
+	for i := 0; i < len(Rows[rowIndex].HasElems); i++ {
+		index = Rows[rowIndex].HasElems[i]
+		coef  = Elems[index].Value
+		bndLo = Cols[Elems[index].InCol].BndLo
+		bndUp = Cols[Elems[index].InCol].BndUp
+
+		// A positive coefficient takes the minimum at the lower bound, and a
+		// negative one at the upper bound.
+		if coef < 0 {
+			bndLo, bndUp = bndUp, bndLo
+		}
 
-		// Skip over any cols that are not still active or that are not empty	
-		if  len(Cols[i].HasElems) > 0 || Cols[i].State != stateActive {
-			continue
+		if bndLo <= -Plinfy || bndLo >= Plinfy {
+			*minInf++
+		} else {
+			*minAct += coef * bndLo
 		}
 
-		// Lower bounds may not be correct.	
-		if Cols[i].BndLo == -Plinfy && Cols[i].BndUp != 0 {
-			log(pWARN, "WARNING: Empty col %s has bounds %f to %f.\n",
-				Cols[i].Name, Cols[i].BndLo, Cols[i].BndUp)
-		}	
+		if bndUp <= -Plinfy || bndUp >= Plinfy {
+			*maxInf++
+		} else {
+			*maxAct += coef * bndUp
+		}
+	} // End for all elements in row
 
-		// Upper bounds may not be correct.	
-		if Cols[i].BndLo != 0 && Cols[i].BndUp != Plinfy {
-			log(pWARN, "WARNING: Empty col %s has bounds %f to %f.\n",
-				Cols[i].Name, Cols[i].BndLo, Cols[i].BndUp)
-		}	
+	return nil
+}
 
-		Cols[i].State = stateDelete
-		_ = updatePsList(psopEmptyCol, -1, i)
-		log(pDEB, "  Col %s removed.\n", Cols[i].Name)
-	
-	} // End for all rows
+//==============================================================================
 
-	if err = delTaggedCols(numDltd); err != nil {
-		return errors.Wrap(err, "delEmptyCols failed")
+// removeActivity removes the contribution (contrib) of a variable at the bound
+// (bound) from the activity (act) of a row, and returns the activity and the number
+// of its infinite contributions (numInf), adjusted accordingly.
+func removeActivity(act float64, numInf int, contrib float64, bound float64) (float64, int) {
+
+	if bound <= -Plinfy || bound >= Plinfy {
+		return act, numInf - 1
 	}
 	
-	if *numDltd != 0 {
-		log(pINFO, "Deleted %d empty columns.\n", *numDltd)		
-	}
-
-	return nil	
+	return act - contrib, numInf
 }
 
 //==============================================================================
-// COLUMN REDUCTION OPERATIONS
-//==============================================================================
-
-// swapCols switches columns specified by source and destination indices 
-// (srcIndex, destIndex) in Cols list and updates all cross-references.
-// In case of failure, it returns an error.
-func swapCols(srcIndex int, destIndex int) error {
-	var tempCol InputCol  // temporary holder for column as we swap them
-	var index        int  // temporary holder for index needed during processing
 
-	// Return error if indices are out of range.
+// isParallel returns true if the two normalized patterns (p1, p2) have the same
+// indices and, within the tolerance for parallel items, the same normalized values.
+func isParallel(p1 psPattern, p2 psPattern) bool {
 
-	if srcIndex < 0 || srcIndex >= len(Cols) {
-		return errors.Errorf("Source index %d out of range in swapCols", srcIndex)
+	if len(p1.Index) != len(p2.Index) || len(p1.Index) == 0 {
+		return false
 	}
 
-	if destIndex < 0 || destIndex >= len(Cols) {
-		return errors.Errorf("Destination index %d out of range in swapCols", destIndex)
+	for i := 0; i < len(p1.Index); i++ {
+		if p1.Index[i] != p2.Index[i] {
+			return false
+		}
+		if math.Abs(p1.Value[i] - p2.Value[i]) > psParTol {
+			return false
+		}
 	}
+	
+	return true
+}
 
-	// Swap references to the two columns in the elements lists.
 
-	for i := 0; i < len(Cols[srcIndex].HasElems); i++ {
-		index = Cols[srcIndex].HasElems[i]
-		Elems[index].InCol = destIndex
-	}
+//==============================================================================
 
-	for i := 0; i < len(Cols[destIndex].HasElems); i++ {
-		index = Cols[destIndex].HasElems[i]
-		Elems[index].InCol = srcIndex
+// postSolve updates the constraint map (pscMap) and variable map (solvedVarMap)
+// referenced in the argument list with information stored in the package global
+// list of presolve operations (psOpList). Effectively, this function merges the
+// results calculated by the solver with results obtained by "reversing" the presolve
+// operations. In case of failure, function returns an error.
+func postSolve(pscMap PsResConMap, solvedVarMap PsResVarMap) error {
+	var rhs    float64  // RHS of row being processed
+	var lhs    float64  // LHS of row being processed
+	var coef   float64  // holder for value of coefficient being processed
+	var ratio  float64  // ratio of deleted to retained coefficients
+	var mrgVal float64  // value of merged column returned by the solver
+	var colLo  float64  // lowest value deleted column may take
+	var colUp  float64  // highest value deleted column may take
+	var dual   float64  // dual value of row being processed
+	var curVar  psCoef  // holder for variable structure being processed
+	var err      error  // error returned by secondary functions called
+
+	// A row which tightened a column bound is recorded only if it changed since
+	// it last tightened a bound, so each operation uses the latest row recorded
+	// up to it.
+	bndRows := make([]psRow, len(psOpList))
+	lastRow := make(map[string]psRow)
+	for i := 0; i < len(psOpList); i++ {
+		if psOpList[i].OpType == psopBndTight {
+			if len(psOpList[i].Row.Coef) > 0 {
+				lastRow[psOpList[i].Row.Name] = psOpList[i].Row
+			}
+			bndRows[i] = lastRow[psOpList[i].Row.Name]
+		}
 	}
+		
+	for i := len(psOpList) - 1; i >= 0; i-- {
+	
+		switch psOpList[i].OpType {
 
-	// Swap the columns.
+		// Empty, Non-binding and Redundant Row --------------------------------
+		case psopEmptyRow, psopNbRow, psopRedRow:
 
-	tempCol         = Cols[destIndex]
-	Cols[destIndex] = Cols[srcIndex]
-	Cols[srcIndex]  = tempCol
+			// The row can never be binding, so its dual is zero.
+			if err = addPstRow(psOpList[i].Row, 0, pscMap, solvedVarMap); err != nil {
+				return errors.Wrap(err, "postSolve failed")
+			}
 
-	return nil
-}
+		// Duplicate Row -------------------------------------------------------
+		case psopDupRow:
 
-//==============================================================================
+			if err = addPstRow(psOpList[i].Row, 0, pscMap, solvedVarMap); err != nil {
+				return errors.Wrap(err, "postSolve failed")
+			}
 
-// delFixedVars deletes all fixed variables and passes back the number of columns 
-// deleted in the numDltd variable.
-// In case of failure, function returns an error.
-func delFixedVars(numDltd *int) error {
-	var index    int  // holder for index of list currently being processed
-	var coef float64  // coefficient value
-	var err    error  // error returned by secondary functions
+			// If the retained row is held at a bound which came from the deleted
+			// row rather than from its own bounds, its dual is transferred to the
+			// deleted row. The reduced costs do not change, since the coefficients
+			// of the deleted row are the ratio times those of the retained row.
+			ratio = psOpList[i].Ratio
+			dual  = getPstDual(pscMap, psOpList[i].Ref)
+			if err = getPstLhs(psOpList[i].Row, solvedVarMap, &lhs); err != nil {
+				return errors.Wrap(err, "postSolve failed")
+			}
+			lhs = lhs / ratio
+
+			if (dual > 0 && math.Abs(lhs - psOpList[i].BndLo) > Featol) ||
+				(dual < 0 && math.Abs(lhs - psOpList[i].BndUp) > Featol) {
+				refMapItem := pscMap[psOpList[i].Ref]
+				refMapItem.Pi   = 0
+				refMapItem.Dual = 0
+				pscMap[psOpList[i].Ref] = refMapItem
+
+				cMapItem := pscMap[psOpList[i].Row.Name]
+				cMapItem.Pi   = dual / ratio
+				cMapItem.Dual = dual / ratio
+				pscMap[psOpList[i].Row.Name] = cMapItem
+			}
 
-	// Initialize variables
-	*numDltd = 0
+		// Forcing Row ---------------------------------------------------------
+		case psopForcingRow:
 
-	log(pINFO, "Looking for fixed variables ...\n")
-	
-	// Look through the columns list for items that have same upper & lower bound
-	for i := 0; i < len(Cols); i++ {
+			if err = addPstRow(psOpList[i].Row, 0, pscMap, solvedVarMap); err != nil {
+				return errors.Wrap(err, "postSolve failed")
+			}
 
-		// Only process active rows, and skip over locked or deleted ones.
-		if Cols[i].State != stateActive {
-			continue
-		}
-		
-		if Cols[i].BndLo != Cols[i].BndUp {
-			// Not a fixed variable, move to the next one.
-			continue
-		}
+			// All variables of the row are at the bound which produces the forced
+			// activity. Find the smallest dual (largest if the row was forced by its
+			// lower bound) of the correct sign for which the reduced costs of all
+			// of them are consistent with those bounds.
+			dual = 0
+			for j := 0; j < len(psOpList[i].Row.Coef); j++ {
+				coef = psOpList[i].Row.Coef[j].Value
+				vMapItem, ok := solvedVarMap[psOpList[i].Row.Coef[j].Name]
+				if !ok {
+					return errors.Errorf("postSolve unable to find value for %s", 
+						psOpList[i].Row.Coef[j].Name)
+				}
 
-		// Tag the column for deletion and add it to the list of cols deleted.
-		log(pDEB, "  Col %s removed.\n", Cols[i].Name)
-		Cols[i].State = stateDelete				
-		_ = updatePsList(psopFixedVar, -1, i)
+				if psOpList[i].AtBound == "U" {
+					dual = math.Min(dual, vMapItem.ReducedCost / coef)
+				} else {
+					dual = math.Max(dual, vMapItem.ReducedCost / coef)
+				}
+			} // End for all variables in row
 
-		// Update RHS for each row where this column appears.
-		for j := 0; j < len(Cols[i].HasElems); j++ {
+			if err = adjPstDual(psOpList[i].Row, dual, pscMap, solvedVarMap); err != nil {
+				return errors.Wrap(err, "postSolve failed")
+			}
 
-			index = Elems[Cols[i].HasElems[j]].InRow
-			coef  = Elems[Cols[i].HasElems[j]].Value
+		// Column Bound Tightened by a Row -------------------------------------
+		case psopBndTight:
 
-			if Rows[index].RHSlo != -Plinfy {
-				Rows[index].RHSlo -= Cols[i].BndLo * coef
+			vMapItem, ok := solvedVarMap[psOpList[i].Col.Name]
+			if !ok {
+				return errors.Errorf("postSolve unable to find value for %s", psOpList[i].Col.Name)
 			}
 
-			if Rows[index].RHSup != Plinfy {
-				Rows[index].RHSup -= Cols[i].BndUp * coef
+			bndRow := bndRows[i]
+			coef    = psOpList[i].Ratio
+			if coef == 0 || bndRow.Name == "" {
+				return errors.Errorf("postSolve unable to find row %s tightening %s",
+					psOpList[i].Row.Name, psOpList[i].Col.Name)
 			}
-			
-		} // End for all rows associated with fixed variable
-				
-	} // End for all columns
-
-
-	if err = delTaggedCols(numDltd); err != nil {
-		return errors.Wrap(err, "delFixedVars failed")
-	}
-	
-	if *numDltd != 0 {
-		log(pINFO, "Deleted %d fixed variables.\n", *numDltd)		
-	}
-	
-	return nil
-}
 
-//==============================================================================
+			// If the variable is held at the bound derived from the row rather than
+			// at its own bound, its reduced cost is due to the row, and is
+			// transferred to the row dual.
+			if (psOpList[i].AtBound == "L" && vMapItem.ReducedCost > 0 &&
+				math.Abs(vMapItem.Value - psOpList[i].Col.BndLo) > Featol) ||
+				(psOpList[i].AtBound == "U" && vMapItem.ReducedCost < 0 &&
+				math.Abs(vMapItem.Value - psOpList[i].Col.BndUp) > Featol) {
+
+				if _, ok = pscMap[bndRow.Name]; !ok {
+					if err = addPstRow(bndRow, 0, pscMap, solvedVarMap); err != nil {
+						return errors.Wrap(err, "postSolve failed")
+					}
+				}
 
-// delTaggedCols finds columns tagged for deletion, moves them to end of list by 
-// swapping with still-active columns, deletes all tagged columns, and updates 
-// cross-references. Function passes back the number of columns deleted in the
-// numDltd variable.
-// In case of failure, function returns an error.
-func delTaggedCols(numDltd *int) error {
-	var err error  // error value received from called functions
+				err = adjPstDual(bndRow, vMapItem.ReducedCost / coef, pscMap, solvedVarMap)
+				if err != nil {
+					return errors.Wrap(err, "postSolve failed")
+				}
+			}
 
-	// Initialize variables
-	*numDltd = 0
+		// Coefficient Tightening and Clique Extension ------------------------
+		case psopCoefTight, psopCliqueExt:
 
-	// Delete all tagged columns, working from the end of the list. Swapping of
-	// columns to put them at the end is done by the deletion function.
+			// The row is still in the model, so the original RHS and type are
+			// restored if the row was returned by the solver. The slack returned
+			// refers to the changed row, and is recalculated from the row saved
+			// before the change. The columns of that row were either kept or
+			// removed by later operations, so all of their values are known.
+			if _, ok := pscMap[psOpList[i].Row.Name]; ok {
+				_ = addConMapItem(pscMap, psOpList[i].Row)
 
-	for i := len(Cols) - 1; i >= 0; i-- {
+				if err = getPstLhs(psOpList[i].Row, solvedVarMap, &lhs); err != nil {
+					return errors.Wrap(err, "postSolve failed")
+				}
 
-		if Cols[i].State == stateDelete {
-			if err = DelCol(i); err != nil {
-				return errors.Wrapf(err, "Failed to delete column %d", i)				
+				cMapItem := pscMap[psOpList[i].Row.Name]
+				cMapItem.Slack = psOpList[i].Row.Rhs - lhs
+				pscMap[psOpList[i].Row.Name] = cMapItem
 			}
+						
+		// Fixed Variable ------------------------------------------------------	
+		case psopFixedVar:
 
-		*numDltd = *numDltd + 1
-		
-		} // End if column tagged for deletion
-	} // End for deleting all tagged columns
+			// Calculate variable value and add it to solved variables map.
+			// There is no row information to transfer for this item, but the
+			// reduced cost is calculated from the duals of the rows of the column.
+			varbMap := make(PsResVarMap)
+			vMapItem := varbMap[psOpList[i].Col.Name]
+			vMapItem.Value       = psOpList[i].Col.BndLo
+			_ = getPstRedCost(psOpList[i].Col, pscMap, &vMapItem.ReducedCost)
+			vMapItem.Status      = psVarStatNA
+			vMapItem.ScaleFactor = psOpList[i].Col.ScaleFactor
+			solvedVarMap[psOpList[i].Col.Name] = vMapItem
 
-	return nil
-}
+		// Empty Column ------------------------------------------------------	
+		case psopEmptyCol:
 
-//==============================================================================
+			// Set the value to the bound dictated by the cost, which was checked
+			// to be finite when the column was removed. Since the column is in no
+			// constraint, its reduced cost is equal to its cost.
+			varbMap := make(PsResVarMap)
+			vMapItem := varbMap[psOpList[i].Col.Name]
+			vMapItem.Value, _    = emptyColValue(psOpList[i].Col.BndLo, psOpList[i].Col.BndUp,
+				psOpList[i].Col.Cost, psOpList[i].Col.Type)
+			vMapItem.ReducedCost = psOpList[i].Col.Cost
+			vMapItem.Status      = psVarStatNA
+			vMapItem.ScaleFactor = psOpList[i].Col.ScaleFactor
+			solvedVarMap[psOpList[i].Col.Name] = vMapItem
+				
+		// Free and Implied Free Column Singleton ------------------------------	
+		case psopFreeCol, psopImplFreeCol:	
 
-// delFreeColSingls deletes free column singletons, defined as a variable 
-// which appears only in the objective function and has bounds from negative
-// infinity to positive infinity. It passes the number of items (rows and cols) 
-// deleted back in the numDltd variable.
-// In case of failure, function returns an error.
-func delFreeColSingls(numDltd *int) error {
-	var rowIndex  int  // holder for index of list item currently being processed
-	var rowsFound int  // number of rows found and deleted
-	var colsFound int  // number of columns found and deleted
-	var err     error  // error received from called functions
+			// First get the RHS and coefficient value
+			rhs = psOpList[i].Row.Rhs
+			lhs = 0
+			coef = 0
+			for j := 0; j < len(psOpList[i].Row.Coef); j++ {
+				curVar.Name = psOpList[i].Row.Coef[j].Name
+				if curVar.Name == psOpList[i].Col.Name {
+					// If this is the varb we are looking for, get coef. for this row
+					coef = psOpList[i].Row.Coef[j].Value					
+				} else {
+					// If different varb, sum up its value times coef (lhs) for this row
+					if psVar, ok := solvedVarMap[curVar.Name]; !ok {
+						return errors.Errorf("postSolve unable to find value for %s", curVar.Name)
+					} else {
+						lhs += psVar.Value * psOpList[i].Row.Coef[j].Value
+					} // End else increment lhs value
+				} // End else not variable we need to solve
+			} // End for all variables in row
 
-	*numDltd = 0
-	rowIndex = -1
-	
-	for i := 0; i < len(Cols); i++ {
+			if coef == 0 {
+				return errors.New("postSolve unable to find coefficient")
+			}
 
-		if len(Cols[i].HasElems) != 1 {
-			// Variable occurs in more than one place, can't be removed.
-			continue
-		}
-		
-		if Cols[i].BndLo != -Plinfy || Cols[i].BndUp != Plinfy {
-			// Not a free variable, can't be removed.
-			continue
-		} 
-		
-		rowIndex =  Elems[Cols[i].HasElems[0]].InRow
-		if rowIndex == ObjRow {
-			// Variable occurs only in objective function, can't be removed.
+			// Find the range of values for which the row is satisfied, and limit
+			// it further by the bounds of the variable. An implied free variable 
+			// always has a value within its bounds that satisfies the row.
+			colLo = psOpList[i].Col.BndLo
+			colUp = psOpList[i].Col.BndUp
+			if coef < 0 {
+				colLo, colUp = colUp, colLo
+			}
+			if psOpList[i].Row.RhsLo > -Plinfy {
+				colLo = math.Max(colLo * coef, psOpList[i].Row.RhsLo - lhs)
+			} else {
+				colLo = colLo * coef
+			}
+			if psOpList[i].Row.RhsUp < Plinfy {
+				colUp = math.Min(colUp * coef, psOpList[i].Row.RhsUp - lhs)
+			} else {
+				colUp = colUp * coef
+			}
+			
+			// Calculate variable value and add it to solved variables map. The 
+			// row is kept at its RHS if possible, and at the nearest bound otherwise.
+			varbMap             := make(PsResVarMap)
+			vMapItem            := varbMap[psOpList[i].Col.Name]
+			vMapItem.Value       = math.Min(math.Max(rhs - lhs, colLo), colUp) / coef
+			vMapItem.ReducedCost = 0
+			vMapItem.Status      = psVarStatNA
+			vMapItem.ScaleFactor = psOpList[i].Col.ScaleFactor
+			solvedVarMap[psOpList[i].Col.Name] = vMapItem
+
+			// Get deleted row details and add it to solved constraints map. The
+			// variable has no cost and is strictly between its bounds, so its 
+			// reduced cost and the dual of the row are zero.
+			constrMap      := make(PsResConMap)
+			cMapItem       := constrMap[psOpList[i].Row.Name]
+			cMapItem.Type        = psOpList[i].Row.Type
+			cMapItem.Rhs         = psOpList[i].Row.Rhs
+			cMapItem.Pi          = 0
+			cMapItem.Dual        = 0
+			cMapItem.Slack       = 0
+			cMapItem.Status      = psConStatNA
+			cMapItem.ScaleFactor = psOpList[i].Row.ScaleFactor
+			pscMap[psOpList[i].Row.Name] = cMapItem
+			
+
+		// Free Column Singleton With Cost -------------------------------------	
+		case psopCostFreeCol:	
+
+			// Sum up the values of the other variables of the row, and find the
+			// coefficient of the variable being recovered.
+			lhs  = 0
+			coef = 0
+			for j := 0; j < len(psOpList[i].Row.Coef); j++ {
+				curVar.Name = psOpList[i].Row.Coef[j].Name
+				if curVar.Name == psOpList[i].Col.Name {
+					coef = psOpList[i].Row.Coef[j].Value					
+				} else {
+					if psVar, ok := solvedVarMap[curVar.Name]; !ok {
+						return errors.Errorf("postSolve unable to find value for %s", curVar.Name)
+					} else {
+						lhs += psVar.Value * psOpList[i].Row.Coef[j].Value
+					}
+				}
+			} // End for all variables in row
+
+			if coef == 0 {
+				return errors.New("postSolve unable to find coefficient")
+			}
+
+			// The row dual is the cost divided by the coefficient, and its sign 
+			// shows which bound of the row is active.
+			dual = psOpList[i].Col.Cost / coef
+			if dual > 0 {
+				rhs = psOpList[i].Row.RhsLo
+			} else {
+				rhs = psOpList[i].Row.RhsUp
+			}
+
+			// Calculate variable value and add it to solved variables map. The
+			// variable is basic, so its reduced cost is zero.
+			varbMap             := make(PsResVarMap)
+			vMapItem            := varbMap[psOpList[i].Col.Name]
+			vMapItem.Value       = (rhs - lhs) / coef
+			vMapItem.ReducedCost = 0
+			vMapItem.Status      = psVarStatNA
+			vMapItem.ScaleFactor = psOpList[i].Col.ScaleFactor
+			solvedVarMap[psOpList[i].Col.Name] = vMapItem
+
+			// Get deleted row details and add it to solved constraints map
+			constrMap      := make(PsResConMap)
+			cMapItem       := constrMap[psOpList[i].Row.Name]
+			cMapItem.Type        = psOpList[i].Row.Type
+			cMapItem.Rhs         = psOpList[i].Row.Rhs
+			cMapItem.Pi          = dual
+			cMapItem.Dual        = dual
+			cMapItem.Slack       = psOpList[i].Row.Rhs - rhs
+			cMapItem.Status      = psConStatNA
+			cMapItem.ScaleFactor = psOpList[i].Row.ScaleFactor
+			pscMap[psOpList[i].Row.Name] = cMapItem
+			
+
+		// Row Singleton -------------------------------------------------------	
+		case psopRowSingltn:
+
+			rhs = psOpList[i].Row.Rhs
+			coef = 0
+			for j := 0; j < len(psOpList[i].Row.Coef); j++ {
+				if psOpList[i].Row.Coef[j].Name == psOpList[i].Col.Name {
+					coef = psOpList[i].Row.Coef[j].Value
+					break
+				} 
+			}
+			
+			if coef == 0 {
+				return errors.New("postSolve unable to find coefficient")
+			}
+			
+			// Calculate variable value and add it to solved variables map
+			varbMap             := make(PsResVarMap)
+			vMapItem            := varbMap[psOpList[i].Col.Name]
+			vMapItem.Value       = rhs / coef
+			_ = getPstRedCost(psOpList[i].Col, pscMap, &vMapItem.ReducedCost)
+			vMapItem.Status      = psVarStatNA
+			vMapItem.ScaleFactor = psOpList[i].Col.ScaleFactor
+			solvedVarMap[psOpList[i].Col.Name] = vMapItem
+
+			// Add the deleted row to solved constraints map. The row is an
+			// equality, so its dual can take up the whole reduced cost of the
+			// variable, which then becomes zero.
+			if err = addPstRow(psOpList[i].Row, 0, pscMap, solvedVarMap); err != nil {
+				return errors.Wrap(err, "postSolve failed")
+			}
+
+			err = adjPstDual(psOpList[i].Row, vMapItem.ReducedCost / coef, pscMap, solvedVarMap)
+			if err != nil {
+				return errors.Wrap(err, "postSolve failed")
+			}
+					
+
+		// Duplicate Column ----------------------------------------------------	
+		case psopDupCol:
+
+			// The solver value of the retained column is the merged value
+			// v = x_ref + ratio * x_col. Find the range of values of the deleted
+			// column for which the retained column stays within its bounds prior to
+			// the merge, and pick the value in that range closest to zero.
+			refMapItem, ok := solvedVarMap[psOpList[i].Ref]
+			if !ok {
+				return errors.Errorf("postSolve unable to find value for %s", psOpList[i].Ref)
+			}
+
+			ratio  = psOpList[i].Ratio
+			mrgVal = refMapItem.Value
+			colLo  = psOpList[i].Col.BndLo
+			colUp  = psOpList[i].Col.BndUp
+			
+			if ratio > 0 {
+				if psOpList[i].BndUp < Plinfy {
+					colLo = math.Max(colLo, (mrgVal - psOpList[i].BndUp) / ratio)
+				}
+				if psOpList[i].BndLo > -Plinfy {
+					colUp = math.Min(colUp, (mrgVal - psOpList[i].BndLo) / ratio)
+				}
+			} else {
+				if psOpList[i].BndLo > -Plinfy {
+					colLo = math.Max(colLo, (mrgVal - psOpList[i].BndLo) / ratio)
+				}
+				if psOpList[i].BndUp < Plinfy {
+					colUp = math.Min(colUp, (mrgVal - psOpList[i].BndUp) / ratio)
+				}
+			} // End else ratio is negative
+
+			if psOpList[i].Col.Type != "R" {
+				colLo = snap(colLo, "L")
+				colUp = snap(colUp, "U")
+			}
+
+			varbMap             := make(PsResVarMap)
+			vMapItem            := varbMap[psOpList[i].Col.Name]
+			vMapItem.Value       = 0
+			if vMapItem.Value < colLo {
+				vMapItem.Value = colLo
+			}
+			if vMapItem.Value > colUp {
+				vMapItem.Value = colUp
+			}
+			vMapItem.ReducedCost = ratio * refMapItem.ReducedCost
+			vMapItem.Status      = psVarStatNA
+			vMapItem.ScaleFactor = psOpList[i].Col.ScaleFactor
+			solvedVarMap[psOpList[i].Col.Name] = vMapItem
+
+			// The retained column keeps the remainder of the merged value.
+			refMapItem.Value = mrgVal - ratio * vMapItem.Value
+			solvedVarMap[psOpList[i].Ref] = refMapItem
+					
+
+		// Row Singleton Converted to Bounds -----------------------------------	
+		case psopRowSnglBnd:
+
+			if len(psOpList[i].Row.Coef) != 1 {
+				return errors.Errorf("postSolve found %d coefficients in singleton row %s",
+					len(psOpList[i].Row.Coef), psOpList[i].Row.Name)
+			}
+			
+			coef = psOpList[i].Row.Coef[0].Value
+			vMapItem, ok := solvedVarMap[psOpList[i].Col.Name]
+			if !ok {
+				return errors.Errorf("postSolve unable to find value for %s", psOpList[i].Col.Name)
+			}
+			lhs = coef * vMapItem.Value
+
+			// The sign of the reduced cost shows which bound of the variable is
+			// active. If it is not the bound the variable had before the row was
+			// converted and the row is at one of its bounds, the reduced cost is 
+			// due to the row, and is transferred to the row dual if the sign is valid.
+			dual = 0
+			if (vMapItem.ReducedCost > 0 && math.Abs(vMapItem.Value - psOpList[i].Col.BndLo) > Featol) ||
+				(vMapItem.ReducedCost < 0 && math.Abs(vMapItem.Value - psOpList[i].Col.BndUp) > Featol) {
+				dual = vMapItem.ReducedCost / coef
+				if (dual > 0 && math.Abs(lhs - psOpList[i].Row.RhsLo) > Featol) ||
+					(dual < 0 && math.Abs(lhs - psOpList[i].Row.RhsUp) > Featol) {
+					dual = 0
+				}
+			}
+
+			if dual != 0 {
+				vMapItem.ReducedCost = 0
+				solvedVarMap[psOpList[i].Col.Name] = vMapItem
+			}
+
+			// Get deleted row details and add it to solved constraints map
+			constrMap      := make(PsResConMap)
+			cMapItem       := constrMap[psOpList[i].Row.Name]
+			cMapItem.Type        = psOpList[i].Row.Type
+			cMapItem.Rhs         = psOpList[i].Row.Rhs
+			cMapItem.Pi          = dual
+			cMapItem.Dual        = dual
+			cMapItem.Slack       = psOpList[i].Row.Rhs - lhs
+			cMapItem.Status      = psConStatNA
+			cMapItem.ScaleFactor = psOpList[i].Row.ScaleFactor
+			pscMap[psOpList[i].Row.Name] = cMapItem
+					
+
+		// Something unknown ---------------------------------------------------	
+		default:
+			return errors.Errorf("Unexpected operation %s in postSolve", psOpList[i].OpType)
+		} // End switch on operation type
+		
+	} // End for processing psOpList
+	
+	return nil
+}
+
+//==============================================================================
+// ROW REDUCTION OPERATIONS
+//==============================================================================
+
+// swapRows switches the rows specified by source and destination indices 
+// (srcIndex, destIndex) in Rows list and updates all cross-references.
+// In case of failure, it returns an error.
+func swapRows(srcIndex int, destIndex int) error {
+	var tempRow InputRow  // holder for row being moved
+	var index        int  // holder for index of item being processed
+
+	// Return error if indices are out of range
+
+	if srcIndex < 0 || srcIndex >= len(Rows) {
+		return errors.Errorf("Source index %d out of range in swapRows", srcIndex)
+	}
+
+	if destIndex < 0 || destIndex >= len(Rows) {
+		return errors.Errorf("Dest. index %d out of range in swapRows", destIndex)
+	}
+
+	// Swap the references to the two rows in the elements lists.
+
+	for i := 0; i < len(Rows[srcIndex].HasElems); i++ {
+		index = Rows[srcIndex].HasElems[i]
+		Elems[index].InRow = destIndex
+	}
+
+	for i := 0; i < len(Rows[destIndex].HasElems); i++ {
+		index = Rows[destIndex].HasElems[i]
+		Elems[index].InRow = srcIndex
+	}
+
+	// Swap the two rows.
+
+	tempRow         = Rows[destIndex]
+	Rows[destIndex] = Rows[srcIndex]
+	Rows[srcIndex]  = tempRow
+
+	return nil
+}
+
+
+//==============================================================================
+
+// DelRow deletes the row specified by index srcRow, and updates all cross references.
+// If srcRow is not already the last row in the list, the row is swapped with the
+// last row and is deleted from the end of the list.
+// In case of failure, it returns an error.
+func DelRow(srcRow int) error {
+	var iCurElem       int   // index of current element
+	var iLastElem      int   // index of last element in list
+	var lastRow        int   // index of last row in list
+	var index          int   // holder for index being processed
+	var jStart         int   // position from which a list of elements is searched
+	var elemList     []int   // list of element associated with item
+	var newElemList  []int   // new element list created after items deleted
+	var tempElem InputElem   // temporary holder for element
+	var err          error   // error received from called functions
+
+	lastRow = len(Rows) - 1
+	
+	// Check that index of row to be deleted is valid.
+	if srcRow < 0 || srcRow > lastRow {
+		return errors.Errorf("Row index %d out of range", srcRow)
+	}
+
+	// If row to be deleted is not the last row, swap rows to put it at end of list.
+	if srcRow != lastRow {
+		if err = swapRows(srcRow, lastRow); err != nil {
+			return errors.Wrap(err, "Row swap failed")
+		}			
+	}
+
+	// Step through the list of elements in the row to be deleted and migrate
+	// them to the end of the elements list by swapping with those that remain.
+	// Use temporary elemList to keep track of all elements that need to be
+	// processed because the HasElems list associated with the current row may be changing.
+	
+	iLastElem = len(Elems) - 1
+	elemList  = Rows[lastRow].HasElems
+	
+	for i := 0; i < len(elemList); i++ {
+
+		iCurElem = elemList[i]
+
+		// Remove element to be deleted from the column where it occurs.
+		index = Elems[iCurElem].InCol
+		newElemList = nil
+		for j := 0; j < len(Cols[index].HasElems); j++ {
+			if Cols[index].HasElems[j] != iCurElem {
+				newElemList = append(newElemList, Cols[index].HasElems[j])
+			}
+		}
+		Cols[index].HasElems = newElemList
+		
+		// Find	the row location of the former last element and update reference.
+		// If it is in the row being deleted, only the entries not yet processed
+		// are searched, since processed entries may hold the same index.
+		index = Elems[iLastElem].InRow
+		jStart = 0
+		if index == lastRow {
+			jStart = i + 1
+		}
+		for j := jStart; j < len(Rows[index].HasElems); j++ {
+			if Rows[index].HasElems[j] == iLastElem {
+				Rows[index].HasElems[j] = iCurElem
+				break
+			}
+		}
+
+		// Find the column location of the former last element and update reference.
+		index = Elems[iLastElem].InCol
+		for j := 0; j < len(Cols[index].HasElems); j++ {
+			if Cols[index].HasElems[j] == iLastElem {
+				Cols[index].HasElems[j] = iCurElem
+				break
+			}
+		}
+		
+		// Swap the elements and update index of next available slot.
+		tempElem         = Elems[iLastElem]
+		Elems[iLastElem] = Elems[iCurElem]
+		Elems[iCurElem]  = tempElem
+		iLastElem--	
+		
+	} // End for all elements of row being deleted.
+
+	// Reslice the rows and elements lists.
+	Elems = append(Elems[:iLastElem + 1])
+	Rows  = append(Rows[:len(Rows) - 1])
+	
+	return nil
+}
+
+//==============================================================================
+
+// DelCol deletes the column specified by srcCol and updates all cross references.
+// If the column to be deleted is not already the last column in the list, it is
+// swapped with the last column and is deleted from the end of the list.
+// In case of failure, it returns an error.
+func DelCol(srcCol int) error {
+	var err          error  // error string returned from other functions
+	var lastCol        int  // index of last column in the global list
+	var iCurElem       int  // index of current element being processed
+	var iLastElem      int  // index of last element in global list
+	var index          int  // general variable for storing indices as needed
+	var jStart         int  // position from which a list of elements is searched
+	var elemList     []int  // list of elements being processed
+	var newElemList  []int  // new list excluding elements that were deleted
+	var tempElem InputElem  // placeholder for swapping items in element list
+
+
+	lastCol = len(Cols) - 1
+
+	// Exit if index we received is out of range.
+	if srcCol < 0 || srcCol > lastCol {
+		return errors.Errorf("Column index %d out of range", srcCol)
+	}
+
+	// If column to be deleted is not already the last one, swap with last column.
+	if srcCol != lastCol {
+		if err = swapCols(srcCol, lastCol); err != nil {
+			return errors.Wrap(err, "Column swap failed")			
+		}	
+	}
+	
+	// Step through the list of elements in the column to be deleted and migrate
+	// them to the end of the elements list by swapping with those that remain.
+	// Use temporary elemList to keep track of all elements that need to be
+	// processed because the Elem list associated with the current column may be changing.
+	
+	iLastElem = len(Elems) - 1
+	elemList  = Cols[lastCol].HasElems
+
+	for i := 0; i < len(elemList); i++ {
+	
+		iCurElem = elemList[i]
+				
+		// Remove element to be deleted from row where it occurs.
+		index       = Elems[iCurElem].InRow
+		newElemList = nil
+		for j := 0; j < len(Rows[index].HasElems); j++ {
+			if Rows[index].HasElems[j] != iCurElem {
+				newElemList = append(newElemList, Rows[index].HasElems[j])
+			}	
+		}
+		Rows[index].HasElems = newElemList
+
+		// Find	the row location of the former last element and update reference.
+		index = Elems[iLastElem].InRow
+		for j := 0; j < len(Rows[index].HasElems); j++ {
+			if Rows[index].HasElems[j] == iLastElem {
+				Rows[index].HasElems[j] = iCurElem
+				break
+			}
+		}
+
+		// Find the column location of the former last element and update reference.
+		// If it is in the column being deleted, only the entries not yet processed
+		// are searched, since processed entries may hold the same index.
+		index = Elems[iLastElem].InCol
+		jStart = 0
+		if index == lastCol {
+			jStart = i + 1
+		}
+		for j := jStart; j < len(Cols[index].HasElems); j++ {
+			if Cols[index].HasElems[j] == iLastElem {
+				Cols[index].HasElems[j] = iCurElem
+				break
+			}
+		}
+		
+		// Swap the elements and update index of next available slot.
+		tempElem          = Elems[iLastElem]
+		Elems[iLastElem] = Elems[iCurElem]
+		Elems[iCurElem]  = tempElem
+
+		iLastElem--				
+	}
+
+	// Reslice the rows and elements lists.
+	Elems = append(Elems[:iLastElem + 1])
+	Cols  = append(Cols[:len(Cols) - 1])
+	
+	return nil
+}
+
+//==============================================================================
+
+// addElem adds an element with the value specified to the row and column 
+// specified by rowIndex and colIndex, and updates all cross references. The
+// caller must ensure that the row and column do not already share an element.
+// In case of failure, it returns an error.
+func addElem(rowIndex int, colIndex int, value float64) error {
+	var newElem InputElem  // element being added
+
+	if rowIndex < 0 || rowIndex >= len(Rows) {
+		return errors.Errorf("Row index %d out of range in addElem", rowIndex)
+	}
+
+	if colIndex < 0 || colIndex >= len(Cols) {
+		return errors.Errorf("Column index %d out of range in addElem", colIndex)
+	}
+
+	newElem.InRow = rowIndex
+	newElem.InCol = colIndex
+	newElem.Value = value
+	
+	Elems = append(Elems, newElem)
+	Rows[rowIndex].HasElems = append(Rows[rowIndex].HasElems, len(Elems) - 1)
+	Cols[colIndex].HasElems = append(Cols[colIndex].HasElems, len(Elems) - 1)
+	
+	return nil
+}
+
+//==============================================================================
+
+// delElem deletes the element specified by elemIndex from the row and column
+// where it occurs, and updates all cross references. If the element is not the
+// last one in the list, the last element is moved into its place.
+// In case of failure, it returns an error.
+func delElem(elemIndex int) error {
+	var iLastElem      int  // index of last element in list
+	var index          int  // holder for index being processed
+	var newElemList  []int  // new element list created after item deleted
+
+	iLastElem = len(Elems) - 1
+
+	if elemIndex < 0 || elemIndex > iLastElem {
+		return errors.Errorf("Element index %d out of range", elemIndex)
+	}
+
+	// Remove the element from the row and column where it occurs.
+	index       = Elems[elemIndex].InRow
+	newElemList = nil
+	for j := 0; j < len(Rows[index].HasElems); j++ {
+		if Rows[index].HasElems[j] != elemIndex {
+			newElemList = append(newElemList, Rows[index].HasElems[j])
+		}
+	}
+	Rows[index].HasElems = newElemList
+
+	index       = Elems[elemIndex].InCol
+	newElemList = nil
+	for j := 0; j < len(Cols[index].HasElems); j++ {
+		if Cols[index].HasElems[j] != elemIndex {
+			newElemList = append(newElemList, Cols[index].HasElems[j])
+		}
+	}
+	Cols[index].HasElems = newElemList
+
+	// Move the last element into the free slot and update its references.
+	if elemIndex != iLastElem {
+		index = Elems[iLastElem].InRow
+		for j := 0; j < len(Rows[index].HasElems); j++ {
+			if Rows[index].HasElems[j] == iLastElem {
+				Rows[index].HasElems[j] = elemIndex
+				break
+			}
+		}
+
+		index = Elems[iLastElem].InCol
+		for j := 0; j < len(Cols[index].HasElems); j++ {
+			if Cols[index].HasElems[j] == iLastElem {
+				Cols[index].HasElems[j] = elemIndex
+				break
+			}
+		}
+
+		Elems[elemIndex] = Elems[iLastElem]
+	} // End if element was not the last one
+
+	Elems = Elems[:iLastElem]
+	
+	return nil
+}
+
+//==============================================================================
+
+// delTaggedRows finds rows tagged for deletion, moves them to end of list by 
+// swapping with still-active rows, deletes all tagged rows, and updates 
+// cross-references. Function passes back the number of rows deleted in the numDltd
+// variable.
+// In case of failure, function returns an error.
+func delTaggedRows(numDltd *int) error {
+	var err error    // error received from called functions
+
+	// Delete any rows tagged for deletion by looking from the end of the list.
+	// Swapping of rows to put them at the end is done by the deletion function.
+	for i := len(Rows) - 1; i >= 0; i-- {
+
+		if Rows[i].State == stateDelete {
+
+			if err = DelRow(i); err != nil {
+				return errors.Wrapf(err, "Failed to delete row %d", i)
+			} // End if row deletion failed
+
+			*numDltd = *numDltd + 1
+
+		} // End if found row to delete
+	} // End for all rows in list
+
+	return nil
+}
+
+//==============================================================================
+
+// delNbRows searches the Rows list for any non-binding rows that are still
+// in the active state and deletes them. It passes back the number of rows deleted
+// in the numDltd variable.
+// In case of failure, function returns an error.
+func delNbRows(numDltd *int) error {
+	var err error  // error received from called functions
+		
+	log(pINFO, "Looking for non-binding rows...\n")
+	
+	*numDltd = 0
+
+	// Skip over rows if they are not active.	
+	for i := 0; i < len(Rows); i++ {
+
+		if Rows[i].State != stateActive {
+			continue
+		}
+		
+		if Rows[i].Type == "N" {
+			Rows[i].State = stateDelete	
+			_ = updatePsList(psopNbRow, i, -1)
+		}	
+	} // End for looping over all rows	
+
+	if err = delTaggedRows(numDltd); err != nil {
+		return errors.Wrap(err, "delNbRows failed")
+	}
+	
+	if *numDltd != 0 {
+		log(pINFO, "Deleted %d non-binding rows.\n", *numDltd)
+	}
+	
+	return nil
+}
+
+//==============================================================================
+
+// delEmptyRows searches the Rows list for any empty rows that are still
+// in the active state and deletes them. It passes back the number of rows deleted
+// in the numDltd variable.
+// In case of failure, function returns an error.
+func delEmptyRows(numDltd *int) error {
+	var err error  // error received from called functions
+		
+	log(pINFO, "Looking for empty rows...\n")
+
+	*numDltd = 0
+	
+	for i := 0; i < len(Rows); i++ {
+
+		// Skip over any rows that are not still active or that are not empty	
+		if  len(Rows[i].HasElems) > 0 || Rows[i].State != stateActive {
+			continue
+		}
+
+		// Lower bounds may not be correct.	
+		if Rows[i].RHSlo == -Plinfy && Rows[i].RHSup != 0 {
+			log(pWARN, "WARNING: Empty row %s has bounds %f to %f.\n",
+				Rows[i].Name, Rows[i].RHSlo, Rows[i].RHSup)
+		}	
+
+		// Upper bounds may not be correct.	
+		if Rows[i].RHSlo != 0 && Rows[i].RHSup == Plinfy {
+			log(pWARN, "WARNING: Empty row %s has bounds %f to %f.\n",
+				Rows[i].Name, Rows[i].RHSlo, Rows[i].RHSup)
+		}	
+
+		// The activity of the row is zero, which must lie within its bounds.
+		if Rows[i].Type != "N" && (Rows[i].RHSlo > Featol || Rows[i].RHSup < -Featol) {
+			log(pERR, "ERROR: Infeasible, empty row %s has bounds %f to %f.\n",
+				Rows[i].Name, Rows[i].RHSlo, Rows[i].RHSup)
+			return emptyRowInfeasible(i)
+		}
+
+		Rows[i].State = stateDelete
+		_ = updatePsList(psopEmptyRow, i, -1)
+		log(pDEB, "  Row %s removed.\n", Rows[i].Name)
+	
+	} // End for all rows
+
+	if err = delTaggedRows(numDltd); err != nil {
+		return errors.Wrap(err, "delEmptyRows failed")
+	}
+	
+	if *numDltd != 0 {
+		log(pINFO, "Deleted %d empty rows.\n", *numDltd)		
+	}
+
+	return nil
+}
+
+//==============================================================================
+
+// delEmptyCols searches the Cols list for any empty columns that are still
+// in the active state, including columns which appear only in the objective 
+// function, and deletes them. Each column is fixed at the bound dictated by the
+// sign of its cost, or at the value closest to zero if it has no cost, and its
+// contribution is added to the objective function constant. If that bound is
+// infinite, the model is flagged as unbounded and the column is left for the solver.
+// It passes back the number of columns deleted in the numDltd variable.
+// In case of failure, function returns an error.
+func delEmptyCols(numDltd *int) error {
+	var numRows      int  // number of constraints in which column appears
+	var cost     float64  // coefficient of the column in the objective function
+	var value    float64  // value at which column is fixed
+	var ok          bool  // false if column can improve the objective without limit
+	var err        error  // error received from called functions
+		
+	log(pINFO, "Looking for empty columns...\n")
+
+	*numDltd = 0
+	
+	for i := 0; i < len(Cols); i++ {
+
+		// Skip over any cols that are not still active	
+		if Cols[i].State != stateActive {
+			continue
+		}
+
+		// Skip over any cols that appear in a constraint.
+		numRows = 0
+		cost    = 0
+		for j := 0; j < len(Cols[i].HasElems); j++ {
+			if Elems[Cols[i].HasElems[j]].InRow == ObjRow {
+				cost = Elems[Cols[i].HasElems[j]].Value
+			} else {
+				numRows++
+			}
+		}
+		
+		if numRows > 0 {
+			continue
+		}
+
+		if value, ok = emptyColValue(Cols[i].BndLo, Cols[i].BndUp, cost, Cols[i].Type); !ok {
+			log(pWARN, "WARNING: Unbounded, empty col %s has cost %f and bounds %f to %f.\n",
+				Cols[i].Name, cost, Cols[i].BndLo, Cols[i].BndUp)
+			psUnbounded = true
+			continue
+		}
+
+		if value < Cols[i].BndLo - Featol || value > Cols[i].BndUp + Featol {
+			log(pERR, "ERROR: Infeasible, no integer value for col %s within bounds %f to %f.\n",
+				Cols[i].Name, Cols[i].BndLo, Cols[i].BndUp)
+			return psInfeasible(fmt.Sprintf("delEmptyCols infeasible, no integer value for %s", 
+				Cols[i].Name), "", Cols[i].Name, colBndRoots(Cols[i].Name))
+		}
+
+		// The RHS of the objective function is the negative of its constant.
+		if cost != 0 {
+			if Rows[ObjRow].RHSlo != -Plinfy {
+				Rows[ObjRow].RHSlo -= cost * value
+			}
+			if Rows[ObjRow].RHSup != Plinfy {
+				Rows[ObjRow].RHSup -= cost * value
+			}
+		}
+
+		Cols[i].State = stateDelete
+		_ = updatePsList(psopEmptyCol, -1, i)
+		log(pDEB, "  Col %s removed, fixed at %f.\n", Cols[i].Name, value)
+	
+	} // End for all rows
+
+	if err = delTaggedCols(numDltd); err != nil {
+		return errors.Wrap(err, "delEmptyCols failed")
+	}
+	
+	if *numDltd != 0 {
+		log(pINFO, "Deleted %d empty columns.\n", *numDltd)		
+	}
+
+	return nil	
+}
+
+//==============================================================================
+
+// findUnbndCols searches the active columns for columns which can improve the
+// objective function without limit. Such a column has an infinite bound in the
+// direction in which its cost decreases, and every row in which it occurs has an
+// infinite bound in the direction in which the column moves the row activity. If
+// the model is feasible, it is unbounded along the ray in which only the column
+// changes. The model is flagged as unbounded and the first ray found is saved for
+// postsolve, but the columns are left for the solver.
+// It passes back the number of columns found in the numFound variable.
+// In case of failure, function returns an error.
+func findUnbndCols(numFound *int) error {
+	var cost     float64  // coefficient of the column in the objective function
+	var dir      float64  // direction in which the column improves the objective
+	var change   float64  // change of row activity when column moves in direction
+	var limited     bool  // true if a row limits the column
+	var index        int  // index of element being processed
+
+	log(pINFO, "Looking for unbounded columns...\n")
+
+	*numFound = 0
+
+	for j := 0; j < len(Cols); j++ {
+		if Cols[j].State != stateActive {
+			continue
+		}
+
+		cost = 0
+		for k := 0; k < len(Cols[j].HasElems); k++ {
+			if Elems[Cols[j].HasElems[k]].InRow == ObjRow {
+				cost = Elems[Cols[j].HasElems[k]].Value
+			}
+		}
+
+		if cost == 0 {
+			continue
+		}
+
+		dir = 1
+		if cost > 0 {
+			dir = -1
+		}
+
+		if (dir > 0 && Cols[j].BndUp < Plinfy) || (dir < 0 && Cols[j].BndLo > -Plinfy) {
+			continue
+		}
+
+		limited = false
+		for k := 0; k < len(Cols[j].HasElems) && !limited; k++ {
+			index = Cols[j].HasElems[k]
+			if Elems[index].InRow == ObjRow || Rows[Elems[index].InRow].Type == "N" {
+				continue
+			}
+
+			change = Elems[index].Value * dir
+			if (change > 0 && Rows[Elems[index].InRow].RHSup < Plinfy) ||
+				(change < 0 && Rows[Elems[index].InRow].RHSlo > -Plinfy) {
+				limited = true
+			}
+		} // End for all elements in column
+
+		if limited {
+			continue
+		}
+
+		log(pWARN, "WARNING: Unbounded, col %s with cost %f is not limited by its rows.\n",
+			Cols[j].Name, cost)
+
+		*numFound++
+		psUnbounded = true
+		if psUnbndRay == nil {
+			psUnbndCol = Cols[j].Name
+			psUnbndRay = map[string]float64{Cols[j].Name: dir}
+		}
+	} // End for all columns
+
+	return nil
+}
+
+//==============================================================================
+
+// delDupRows searches the Rows list for duplicate rows, i.e. active constraints
+// whose coefficients are proportional to those of another active constraint.
+// Candidates are found by hashing the normalized row patterns, so only rows with
+// the same hash need to be compared. The bounds of each duplicate row are merged
+// into those of the row that is retained, and the duplicate is deleted and added
+// to the presolve list together with the name of the retained row and the ratio
+// of their coefficients, so that duals can be attributed during postsolve.
+// The function passes back the number of rows deleted in the numDltd variable.
+// In case of failure, or if the merged bounds conflict and the model is
+// infeasible, function returns an error.
+func delDupRows(numDltd *int) error {
+	var patterns []psPattern        // normalized pattern of each row
+	var buckets  map[uint64][]int   // rows retained so far grouped by pattern hash
+	var colIndex []int              // column indices of row being processed
+	var colValue []float64          // coefficients of row being processed
+	var keep     int                // index of row retained in place of duplicate
+	var found    bool               // true if row is parallel to a retained row
+	var ratio    float64            // ratio of duplicate to retained coefficients
+	var newLo    float64            // lower bound of duplicate in terms of retained row
+	var newUp    float64            // upper bound of duplicate in terms of retained row
+	var oldLo    float64            // lower bound of retained row before merging
+	var oldUp    float64            // upper bound of retained row before merging
+	var iel      int                // index of element being processed
+	var err      error              // error received from called functions
+
+	log(pINFO, "Looking for duplicate rows...\n")
+
+	*numDltd = 0
+
+	// The gradient vector lengths are used to normalize the rows, and must be
+	// recalculated since columns may have been removed since they were set.
+	_ = calcGradVec()
+
+	patterns = make([]psPattern, len(Rows))
+	buckets  = make(map[uint64][]int)
+
+	for i := 0; i < len(Rows); i++ {
+
+		// Skip over locked, deleted, non-binding, and empty rows.
+		if Rows[i].State != stateActive || Rows[i].Type == "N" || len(Rows[i].HasElems) == 0 {
+			continue
+		}
+
+		colIndex = colIndex[:0]
+		colValue = colValue[:0]
+		for j := 0; j < len(Rows[i].HasElems); j++ {
+			iel      = Rows[i].HasElems[j]
+			colIndex = append(colIndex, Elems[iel].InCol)
+			colValue = append(colValue, Elems[iel].Value)
+		}
+
+		if err = makePattern(colIndex, colValue, Rows[i].GradVecLen, &patterns[i]); err != nil {
+			return errors.Wrapf(err, "delDupRows failed on row %s", Rows[i].Name)
+		}
+
+		// Compare with rows already retained that have the same hash.
+		found = false
+		for _, keep = range buckets[patterns[i].Hash] {
+			if isParallel(patterns[i], patterns[keep]) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			buckets[patterns[i].Hash] = append(buckets[patterns[i].Hash], i)
+			continue
+		}
+
+		// Row i is a multiple (ratio) of the retained row. Express its bounds in
+		// terms of the retained row, which reverses them if the ratio is negative.
+		ratio = patterns[i].Scale / patterns[keep].Scale
+		newLo = -Plinfy
+		newUp =  Plinfy
+
+		if ratio > 0 {
+			if Rows[i].RHSlo > -Plinfy {
+				newLo = Rows[i].RHSlo / ratio
+			}
+			if Rows[i].RHSup < Plinfy {
+				newUp = Rows[i].RHSup / ratio
+			}
+		} else {
+			if Rows[i].RHSup < Plinfy {
+				newLo = Rows[i].RHSup / ratio
+			}
+			if Rows[i].RHSlo > -Plinfy {
+				newUp = Rows[i].RHSlo / ratio
+			}
+		} // End else ratio is negative
+
+		// Merge the bounds into the retained row.
+		oldLo = Rows[keep].RHSlo
+		oldUp = Rows[keep].RHSup
+
+		if newLo > Rows[keep].RHSlo {
+			Rows[keep].RHSlo = newLo
+		}
+		if newUp < Rows[keep].RHSup {
+			Rows[keep].RHSup = newUp
+		}
+
+		if Rows[keep].RHSlo > Rows[keep].RHSup + Featol {
+			log(pERR, "ERROR: Infeasible, duplicate rows %s and %s have conflicting bounds.\n",
+				Rows[i].Name, Rows[keep].Name)
+			roots := append(rowBndRoots(i), rowBndRoots(keep)...)
+			roots  = append(roots, psBndRowFix[Rows[i].Name]...)
+			roots  = append(roots, psBndRowFix[Rows[keep].Name]...)
+			return psInfeasible(fmt.Sprintf("delDupRows infeasible, conflicting bounds on rows %s and %s",
+				Rows[i].Name, Rows[keep].Name), Rows[keep].Name, "", roots)
+		}
+
+		if Rows[keep].RHSup - Rows[keep].RHSlo <= Featol {
+			Rows[keep].RHSup = Rows[keep].RHSlo
+		}
+		Rows[keep].Type = rowTypeFromBounds(Rows[keep].RHSlo, Rows[keep].RHSup)
+
+		// Tag the duplicate for deletion and record which row replaced it.
+		Rows[i].State = stateDelete
+		_ = updatePsList(psopDupRow, i, -1)
+		_ = setPsRef(Rows[keep].Name, ratio, oldLo, oldUp)
+		log(pDEB, "  Row %s removed, duplicate of %s.\n", Rows[i].Name, Rows[keep].Name)
+
+	} // End for all rows
+
+	if err = delTaggedRows(numDltd); err != nil {
+		return errors.Wrap(err, "delDupRows failed")
+	}
+
+	if *numDltd != 0 {
+		log(pINFO, "Deleted %d duplicate rows.\n", *numDltd)
+	}
+
+	return nil
+}
+
+//==============================================================================
+
+// delForcingRows uses the minimum and maximum activity of each active row to find
+// rows which are infeasible, redundant, or forcing. A row is redundant if it is
+// satisfied for all values of its variables within their bounds, and it is simply
+// removed. A row is forcing if its minimum activity equals its upper bound, or its
+// maximum activity equals its lower bound, in which case the only feasible 
+// solution has every variable in the row at the bound that produces that activity.
+// The variables are fixed at those bounds, to be removed with other fixed variables,
+// and the row is removed. All removed rows are added to the presolve list.
+// The function passes back the number of rows deleted in the numDltd variable.
+// In case of failure, or if a row can never be satisfied and the model is
+// infeasible, function returns an error.
+func delForcingRows(numDltd *int) error {
+	var minAct   float64  // minimum activity of row being processed
+	var maxAct   float64  // maximum activity of row being processed
+	var minInf       int  // number of infinite contributions to minimum activity
+	var maxInf       int  // number of infinite contributions to maximum activity
+	var atUpper     bool  // true if the minimum activity forces the upper bound
+	var atLower     bool  // true if the maximum activity forces the lower bound
+	var colIndex     int  // index of column being fixed
+	var coef     float64  // coefficient of column being fixed
+	var newBound float64  // bound at which column is fixed
+	var prevLo   float64  // lower bound of column before it was fixed
+	var prevUp   float64  // upper bound of column before it was fixed
+	var rowSide   string  // bound of the row ("L" or "U") forcing the columns
+	var numFixed     int  // number of columns fixed by forcing rows
+	var numForced    int  // number of forcing rows found
+	var err        error  // error received from called functions
+
+	log(pINFO, "Looking for forcing and redundant rows...\n")
+
+	*numDltd = 0
+
+	for i := 0; i < len(Rows); i++ {
+
+		// Skip over locked, deleted, non-binding, and empty rows.
+		if Rows[i].State != stateActive || Rows[i].Type == "N" || len(Rows[i].HasElems) == 0 {
+			continue
+		}
+
+		if err = calcRowActivity(i, &minAct, &maxAct, &minInf, &maxInf); err != nil {
+			return errors.Wrap(err, "delForcingRows failed")
+		}
+
+		// The row can never be satisfied if its activity cannot reach either bound.
+		if (minInf == 0 && Rows[i].RHSup < Plinfy && minAct > Rows[i].RHSup + Featol) ||
+			(maxInf == 0 && Rows[i].RHSlo > -Plinfy && maxAct < Rows[i].RHSlo - Featol) {
+			log(pERR, "ERROR: Infeasible, row %s activity %f to %f outside bounds %f to %f.\n",
+				Rows[i].Name, minAct, maxAct, Rows[i].RHSlo, Rows[i].RHSup)
+			return psInfeasible(fmt.Sprintf("delForcingRows infeasible, activity of row %s outside its bounds", 
+				Rows[i].Name), Rows[i].Name, "", rowBndRoots(i))
+		}
+
+		// The row is redundant if its activity can never violate either bound.
+		if (Rows[i].RHSlo <= -Plinfy || (minInf == 0 && minAct >= Rows[i].RHSlo - Featol)) &&
+			(Rows[i].RHSup >= Plinfy || (maxInf == 0 && maxAct <= Rows[i].RHSup + Featol)) {
+			Rows[i].State = stateDelete
+			_ = updatePsList(psopRedRow, i, -1)
+			log(pDEB, "  Row %s removed, redundant.\n", Rows[i].Name)
+			continue
+		}
+
+		atUpper = minInf == 0 && Rows[i].RHSup < Plinfy && math.Abs(minAct - Rows[i].RHSup) <= Featol
+		atLower = maxInf == 0 && Rows[i].RHSlo > -Plinfy && math.Abs(maxAct - Rows[i].RHSlo) <= Featol
+		if !atUpper && !atLower {
+			continue
+		}
+
+		rowSide = "L"
+		if atUpper {
+			rowSide = "U"
+		}
+
+		// Fix each variable at the bound which produces the forced activity.
+		for j := 0; j < len(Rows[i].HasElems); j++ {
+			colIndex = Elems[Rows[i].HasElems[j]].InCol
+			coef     = Elems[Rows[i].HasElems[j]].Value
+
+			if (coef > 0) == atUpper {
+				newBound = Cols[colIndex].BndLo
+			} else {
+				newBound = Cols[colIndex].BndUp
+			}
+
+			if Cols[colIndex].Type == "I" && !isInteger(newBound) {
+				log(pERR, "ERROR: Infeasible, row %s forces integer col %s to %f.\n",
+					Rows[i].Name, Cols[colIndex].Name, newBound)
+				return psInfeasible(fmt.Sprintf("delForcingRows infeasible, fractional value for %s", 
+					Cols[colIndex].Name), Rows[i].Name, Cols[colIndex].Name, rowBndRoots(i))
+			}
+
+			if Cols[colIndex].BndLo != Cols[colIndex].BndUp {
+				prevLo = Cols[colIndex].BndLo
+				prevUp = Cols[colIndex].BndUp
+				Cols[colIndex].BndLo = newBound
+				Cols[colIndex].BndUp = newBound
+				numFixed++
+
+				if newBound == prevLo {
+					recBndStep(colIndex, "U", prevUp, i, rowSide)
+				} else {
+					recBndStep(colIndex, "L", prevLo, i, rowSide)
+				}
+			}
+		} // End for all elements in row
+
+		Rows[i].State = stateDelete
+		_ = updatePsList(psopForcingRow, i, -1)
+		if atUpper {
+			_ = setPsAtBound("U")
+		} else {
+			_ = setPsAtBound("L")
+		}
+		log(pDEB, "  Row %s removed, forcing all its variables to a bound.\n", Rows[i].Name)
+		numForced++
+
+	} // End for all rows
+
+	if err = delTaggedRows(numDltd); err != nil {
+		return errors.Wrap(err, "delForcingRows failed")
+	}
+
+	if *numDltd != 0 {
+		log(pINFO, "Deleted %d forcing and %d redundant rows, fixed %d variables.\n",
+			numForced, *numDltd - numForced, numFixed)
+	}
+
+	return nil
+}
+
+//==============================================================================
+
+// tightenCoefs strengthens the coefficients of integer variables in the inequality
+// rows of a MILP model. Each row is processed in its "less than" form, and is only
+// considered if its maximum activity is finite and exceeds the RHS. If an integer
+// variable moving one unit away from the bound giving its maximum contribution
+// makes the row redundant, the coefficient and RHS are both reduced by the excess,
+// which tightens the LP relaxation without changing the set of integer solutions.
+// Each row changed is added to the presolve list as it was before the change.
+// The function passes back the number of rows changed in the numChgd variable.
+// The function does nothing if the model is not a MIP.
+// In case of failure, function returns an error.
+func tightenCoefs(numChgd *int) error {
+	var minAct   float64  // minimum activity of row being processed
+	var maxAct   float64  // maximum activity of row being processed
+	var minInf       int  // number of infinite contributions to minimum activity
+	var maxInf       int  // number of infinite contributions to maximum activity
+	var sign     float64  // 1 for "less than" rows, -1 for "greater than" rows
+	var rhs      float64  // RHS of row in its "less than" form
+	var actMax   float64  // maximum activity of row in its "less than" form
+	var numInf       int  // number of infinite contributions to actMax
+	var index        int  // index of element being processed
+	var colIndex     int  // index of column being processed
+	var coef     float64  // coefficient of column in the "less than" form
+	var bound    float64  // bound at which column gives its maximum contribution
+	var delta    float64  // amount by which coefficient and RHS are reduced
+	var rowChgd     bool  // true if row has already been added to presolve list
+	var numCoefs     int  // number of coefficients changed
+	var err        error  // error received from called functions
+
+	*numChgd = 0
+
+	if !isMip() {
+		return nil
+	}
+
+	log(pINFO, "Tightening coefficients of MILP rows...\n")
+
+	for i := 0; i < len(Rows); i++ {
+
+		// Skip over locked, deleted, and empty rows.
+		if Rows[i].State != stateActive || len(Rows[i].HasElems) == 0 {
+			continue
+		}
+
+		// Only one-sided inequalities are tightened, so equality, ranged and
+		// non-binding rows are left as they are.
+		if Rows[i].RHSlo <= -Plinfy && Rows[i].RHSup < Plinfy {
+			sign = 1
+			rhs  = Rows[i].RHSup
+		} else if Rows[i].RHSup >= Plinfy && Rows[i].RHSlo > -Plinfy {
+			sign = -1
+			rhs  = -Rows[i].RHSlo
+		} else {
+			continue
+		}
+
+		if err = calcRowActivity(i, &minAct, &maxAct, &minInf, &maxInf); err != nil {
+			return errors.Wrap(err, "tightenCoefs failed")
+		}
+
+		if sign > 0 {
+			actMax, numInf = maxAct, maxInf
+		} else {
+			actMax, numInf = -minAct, minInf
+		}
+
+		// Nothing can be done unless the row may actually become binding.
+		if numInf != 0 || actMax <= rhs + Featol {
+			continue
+		}
+
+		rowChgd = false
+
+		for j := 0; j < len(Rows[i].HasElems); j++ {
+			index    = Rows[i].HasElems[j]
+			colIndex = Elems[index].InCol
+			coef     = sign * Elems[index].Value
+
+			if Cols[colIndex].Type != "I" || Cols[colIndex].BndLo == Cols[colIndex].BndUp {
+				continue
+			}
+
+			// The excess is the amount by which the row is redundant when the
+			// column is one unit away from the bound of its maximum contribution.
+			if coef > 0 {
+				bound = Cols[colIndex].BndUp
+				delta = rhs - actMax + coef
+			} else {
+				bound = Cols[colIndex].BndLo
+				delta = rhs - actMax - coef
+			}
+
+			if delta <= Featol {
+				continue
+			}
+
+			if !rowChgd {
+				_ = updatePsList(psopCoefTight, i, -1)
+				rowChgd = true
+				*numChgd++
+			}
+
+			if coef < 0 {
+				delta = -delta
+			}
+
+			log(pDEB, "  Row %s col %s coefficient changed from %f to %f.\n",
+				Rows[i].Name, Cols[colIndex].Name, sign * coef, sign * (coef - delta))
+
+			Elems[index].Value = sign * (coef - delta)
+			rhs    -= delta * bound
+			actMax -= delta * bound
+			numCoefs++
+		} // End for all elements in row
+
+		if rowChgd {
+			if sign > 0 {
+				Rows[i].RHSup = rhs
+			} else {
+				Rows[i].RHSlo = -rhs
+			}
+		}
+
+	} // End for all rows
+
+	if *numChgd != 0 {
+		if err = calcGradVec(); err != nil {
+			return errors.Wrap(err, "tightenCoefs failed")
+		}
+
+		log(pINFO, "Tightened %d coefficients in %d rows.\n", numCoefs, *numChgd)
+	}
+
+	return nil
+}
+
+//==============================================================================
+// COLUMN REDUCTION OPERATIONS
+//==============================================================================
+
+// swapCols switches columns specified by source and destination indices 
+// (srcIndex, destIndex) in Cols list and updates all cross-references.
+// In case of failure, it returns an error.
+func swapCols(srcIndex int, destIndex int) error {
+	var tempCol InputCol  // temporary holder for column as we swap them
+	var index        int  // temporary holder for index needed during processing
+
+	// Return error if indices are out of range.
+
+	if srcIndex < 0 || srcIndex >= len(Cols) {
+		return errors.Errorf("Source index %d out of range in swapCols", srcIndex)
+	}
+
+	if destIndex < 0 || destIndex >= len(Cols) {
+		return errors.Errorf("Destination index %d out of range in swapCols", destIndex)
+	}
+
+	// Swap references to the two columns in the elements lists.
+
+	for i := 0; i < len(Cols[srcIndex].HasElems); i++ {
+		index = Cols[srcIndex].HasElems[i]
+		Elems[index].InCol = destIndex
+	}
+
+	for i := 0; i < len(Cols[destIndex].HasElems); i++ {
+		index = Cols[destIndex].HasElems[i]
+		Elems[index].InCol = srcIndex
+	}
+
+	// Swap the columns.
+
+	tempCol         = Cols[destIndex]
+	Cols[destIndex] = Cols[srcIndex]
+	Cols[srcIndex]  = tempCol
+
+	return nil
+}
+
+//==============================================================================
+
+// delFixedVars deletes all fixed variables and passes back the number of columns 
+// deleted in the numDltd variable.
+// In case of failure, function returns an error.
+func delFixedVars(numDltd *int) error {
+	var index    int  // holder for index of list currently being processed
+	var coef float64  // coefficient value
+	var err    error  // error returned by secondary functions
+
+	// Initialize variables
+	*numDltd = 0
+
+	log(pINFO, "Looking for fixed variables ...\n")
+	
+	// Look through the columns list for items that have same upper & lower bound
+	for i := 0; i < len(Cols); i++ {
+
+		// Only process active rows, and skip over locked or deleted ones.
+		if Cols[i].State != stateActive {
+			continue
+		}
+		
+		if Cols[i].BndLo != Cols[i].BndUp {
+			// Not a fixed variable, move to the next one.
+			continue
+		}
+
+		if Cols[i].Type == "I" && !isInteger(Cols[i].BndLo) {
+			log(pERR, "ERROR: Infeasible, integer col %s fixed at %f.\n", 
+				Cols[i].Name, Cols[i].BndLo)
+			return psInfeasible(fmt.Sprintf("delFixedVars infeasible, fractional value for %s", 
+				Cols[i].Name), "", Cols[i].Name, colBndRoots(Cols[i].Name))
+		}
+
+		// Tag the column for deletion and add it to the list of cols deleted.
+		log(pDEB, "  Col %s removed.\n", Cols[i].Name)
+		Cols[i].State = stateDelete				
+		_ = updatePsList(psopFixedVar, -1, i)
+
+		// Update RHS for each row where this column appears.
+		for j := 0; j < len(Cols[i].HasElems); j++ {
+
+			index = Elems[Cols[i].HasElems[j]].InRow
+			coef  = Elems[Cols[i].HasElems[j]].Value
+
+			if Rows[index].RHSlo != -Plinfy {
+				Rows[index].RHSlo -= Cols[i].BndLo * coef
+			}
+
+			if Rows[index].RHSup != Plinfy {
+				Rows[index].RHSup -= Cols[i].BndUp * coef
+			}
+			
+		} // End for all rows associated with fixed variable
+				
+	} // End for all columns
+
+
+	if err = delTaggedCols(numDltd); err != nil {
+		return errors.Wrap(err, "delFixedVars failed")
+	}
+	
+	if *numDltd != 0 {
+		log(pINFO, "Deleted %d fixed variables.\n", *numDltd)		
+	}
+	
+	return nil
+}
+
+//==============================================================================
+
+// delTaggedCols finds columns tagged for deletion, moves them to end of list by 
+// swapping with still-active columns, deletes all tagged columns, and updates 
+// cross-references. Function passes back the number of columns deleted in the
+// numDltd variable.
+// In case of failure, function returns an error.
+func delTaggedCols(numDltd *int) error {
+	var err error  // error value received from called functions
+
+	// Initialize variables
+	*numDltd = 0
+
+	// Delete all tagged columns, working from the end of the list. Swapping of
+	// columns to put them at the end is done by the deletion function.
+
+	for i := len(Cols) - 1; i >= 0; i-- {
+
+		if Cols[i].State == stateDelete {
+			if err = DelCol(i); err != nil {
+				return errors.Wrapf(err, "Failed to delete column %d", i)				
+			}
+
+		*numDltd = *numDltd + 1
+		
+		} // End if column tagged for deletion
+	} // End for deleting all tagged columns
+
+	return nil
+}
+
+//==============================================================================
+
+// moveColCost transfers the cost of the column specified by colIndex, which is to
+// be removed with the row specified by rowIndex, to the other columns of the row
+// and to the objective function constant. The column value is expressed from the
+// row at its active bound (rhs), so its cost is replaced by the row dual (dual)
+// times the row, less the row dual times rhs. Objective coefficients which become
+// zero are removed.
+// In case of failure, function returns an error.
+func moveColCost(rowIndex int, colIndex int, dual float64, rhs float64) error {
+	var rowCols  []int      // columns of the row, other than the one removed
+	var rowCoefs []float64  // coefficients of the other columns of the row
+	var objElem  int        // index of objective element of column being updated
+	var iel      int        // index of element being processed
+	var err      error      // error received from called functions
+
+	// Collect the other columns first, since the elements may be moved.
+	for j := 0; j < len(Rows[rowIndex].HasElems); j++ {
+		iel = Rows[rowIndex].HasElems[j]
+		if Elems[iel].InCol != colIndex {
+			rowCols  = append(rowCols,  Elems[iel].InCol)
+			rowCoefs = append(rowCoefs, Elems[iel].Value)
+		}
+	}
+
+	for k := 0; k < len(rowCols); k++ {
+
+		objElem = -1
+		for j := 0; j < len(Cols[rowCols[k]].HasElems); j++ {
+			if Elems[Cols[rowCols[k]].HasElems[j]].InRow == ObjRow {
+				objElem = Cols[rowCols[k]].HasElems[j]
+				break
+			}
+		}
+
+		if objElem < 0 {
+			if err = addElem(ObjRow, rowCols[k], -dual * rowCoefs[k]); err != nil {
+				return errors.Wrap(err, "moveColCost failed")
+			}
+			continue
+		}
+
+		Elems[objElem].Value -= dual * rowCoefs[k]
+		if math.Abs(Elems[objElem].Value) <= psParTol * (1 + math.Abs(dual * rowCoefs[k])) {
+			if err = delElem(objElem); err != nil {
+				return errors.Wrap(err, "moveColCost failed")
+			}
+		}
+	} // End for all other columns of the row
+
+	// The RHS of the objective function is the negative of its constant.
+	if Rows[ObjRow].RHSlo != -Plinfy {
+		Rows[ObjRow].RHSlo -= dual * rhs
+	}
+	if Rows[ObjRow].RHSup != Plinfy {
+		Rows[ObjRow].RHSup -= dual * rhs
+	}
+
+	return nil
+}
+
+//==============================================================================
+
+// delFreeColSingls deletes free column singletons, defined as a variable which
+// appears in a single constraint, and possibly in the objective function.
+// A variable is free if its bounds are from negative infinity to positive infinity,
+// or implied free if the bounds on its value derived from the constraint and the
+// bounds of the other variables in it lie within its own bounds, so those can
+// never be active. Integer variables are not removed, since their value is
+// calculated from the constraint during postsolve. The constraint can
+// always be satisfied by the variable and is removed together with it. 
+// If the variable has a cost, the constraint is used to express the variable in 
+// terms of the other variables in it, and the cost is transferred to them and to 
+// the objective function constant. The constraint dual is equal to the cost
+// divided by the coefficient, and its sign determines which bound of an inequality 
+// is active. If that bound is infinite, the model is unbounded or infeasible, and
+// the variable is left for the solver.
+// It passes the number of items (rows and cols) deleted back in the numDltd variable.
+// In case of failure, function returns an error.
+func delFreeColSingls(numDltd *int) error {
+	var rowIndex     int  // holder for index of list item currently being processed
+	var numRows      int  // number of constraints in which variable appears
+	var rowsFound    int  // number of rows found and deleted
+	var colsFound    int  // number of columns found and deleted
+	var minAct   float64  // minimum activity of the constraint
+	var maxAct   float64  // maximum activity of the constraint
+	var minInf       int  // number of infinite contributions to minimum activity
+	var maxInf       int  // number of infinite contributions to maximum activity
+	var coef     float64  // coefficient of the variable in the constraint
+	var cost     float64  // coefficient of the variable in the objective function
+	var rhs      float64  // bound of the constraint which is active
+	var implLo   float64  // lower bound of variable implied by the constraint
+	var implUp   float64  // upper bound of variable implied by the constraint
+	var isFree      bool  // true if the variable is free
+	var err        error  // error received from called functions
+
+	*numDltd = 0
+	rowIndex = -1
+	
+	for i := 0; i < len(Cols); i++ {
+
+		if Cols[i].State != stateActive {
+			continue
+		}
+
+		// Find the constraints in which the variable occurs, and its cost.
+		numRows = 0
+		cost    = 0
+		for j := 0; j < len(Cols[i].HasElems); j++ {
+			if Elems[Cols[i].HasElems[j]].InRow == ObjRow {
+				cost = Elems[Cols[i].HasElems[j]].Value
+			} else {
+				rowIndex = Elems[Cols[i].HasElems[j]].InRow
+				coef     = Elems[Cols[i].HasElems[j]].Value
+				numRows++
+			}
+		} // End for all elements in column
+		
+		if numRows == 0 && cost != 0 {
+			// Variable occurs only in objective function, removed with empty columns.
 			log(pDEB, "Variable %s in objective %s, not in any constraint.\n",
 				Cols[i].Name, Rows[ObjRow].Name)
 			continue
 		}
 		
-		// Tag the column and row for deletion, and add them to postsolve list.
-		
-		log(pINFO, "  Row %s and col %s removed.\n", Rows[rowIndex].Name, Cols[i].Name)
+		if numRows != 1 {
+			// Variable occurs in more than one place, can't be removed.
+			continue
+		}
+
+		if Rows[rowIndex].State != stateActive || Rows[rowIndex].Type == "N" {
+			// Constraint already removed with another variable, or non-binding.
+			continue
+		}
+		
+		// The value of the variable is calculated from the constraint during
+		// postsolve, and would not be integer in general.
+		if Cols[i].Type != "R" {
+			continue
+		}
+
+		isFree = Cols[i].BndLo == -Plinfy && Cols[i].BndUp == Plinfy
+
+		if !isFree {
+
+			// Find the activity of the other variables in the constraint by removing
+			// the contribution of this variable from the activity of the constraint.
+			if err = calcRowActivity(rowIndex, &minAct, &maxAct, &minInf, &maxInf); err != nil {
+				return errors.Wrap(err, "delFreeColSingls failed")
+			}
+		
+			if coef > 0 {
+				minAct, minInf = removeActivity(minAct, minInf, coef * Cols[i].BndLo, Cols[i].BndLo)
+				maxAct, maxInf = removeActivity(maxAct, maxInf, coef * Cols[i].BndUp, Cols[i].BndUp)
+			} else {
+				minAct, minInf = removeActivity(minAct, minInf, coef * Cols[i].BndUp, Cols[i].BndUp)
+				maxAct, maxInf = removeActivity(maxAct, maxInf, coef * Cols[i].BndLo, Cols[i].BndLo)
+			}
+
+			// Bounds implied by RHSlo <= coef * x + others <= RHSup.
+			implLo = -Plinfy
+			implUp =  Plinfy
+		
+			if coef > 0 {
+				if Rows[rowIndex].RHSlo > -Plinfy && maxInf == 0 {
+					implLo = (Rows[rowIndex].RHSlo - maxAct) / coef
+				}
+				if Rows[rowIndex].RHSup < Plinfy && minInf == 0 {
+					implUp = (Rows[rowIndex].RHSup - minAct) / coef
+				}
+			} else {
+				if Rows[rowIndex].RHSup < Plinfy && minInf == 0 {
+					implLo = (Rows[rowIndex].RHSup - minAct) / coef
+				}
+				if Rows[rowIndex].RHSlo > -Plinfy && maxInf == 0 {
+					implUp = (Rows[rowIndex].RHSlo - maxAct) / coef
+				}
+			} // End else coefficient is negative
+
+			if (Cols[i].BndLo > -Plinfy && implLo < Cols[i].BndLo) ||
+				(Cols[i].BndUp < Plinfy && implUp > Cols[i].BndUp) {
+				// Bounds of the variable may be active, can't be removed.
+				continue
+			}
+		} // End if variable is not declared free
+
+		if cost == 0 {
+
+			// Tag the column and row for deletion, and add them to postsolve list.
+			log(pINFO, "  Row %s and col %s removed.\n", Rows[rowIndex].Name, Cols[i].Name)
+
+			Cols[i].State = stateDelete
+			Rows[rowIndex].State = stateDelete
+			if isFree {
+				_ = updatePsList(psopFreeCol, rowIndex, i)
+			} else {
+				_ = updatePsList(psopImplFreeCol, rowIndex, i)
+			}
+			continue
+		} // End if variable has no cost
+
+		// The dual of the constraint is cost / coef. A positive dual makes the lower
+		// bound of the constraint active, and a negative one the upper bound.
+		if cost / coef > 0 {
+			rhs = Rows[rowIndex].RHSlo
+		} else {
+			rhs = Rows[rowIndex].RHSup
+		}
+
+		if rhs <= -Plinfy || rhs >= Plinfy {
+			log(pWARN, "WARNING: Free col %s with cost in row %s, model may be unbounded.\n",
+				Cols[i].Name, Rows[rowIndex].Name)
+			continue
+		}
+
+		log(pINFO, "  Row %s and col %s with cost removed.\n", Rows[rowIndex].Name, Cols[i].Name)
+
+		_ = updatePsList(psopCostFreeCol, rowIndex, i)
+		if err = moveColCost(rowIndex, i, cost / coef, rhs); err != nil {
+			return errors.Wrap(err, "delFreeColSingls failed")
+		}
+		
+		Cols[i].State = stateDelete
+		Rows[rowIndex].State = stateDelete
+						
+	} // End for all columns	
+
+	// Delete the rows and columns, if row deletion fails return at that point,
+	// otherwise delete columns and return with the appropriate return code and
+	// number of items deleted.
+	
+	if err = delTaggedRows(&rowsFound); err != nil {
+		*numDltd = rowsFound
+		return errors.Wrap(err, "delFreeColSingls row deletion failed")	
+	}
+	
+	err = delTaggedCols(&colsFound)
+	*numDltd = rowsFound + colsFound
+	if err != nil {
+		return errors.Wrap(err, "delFreeColSingls col deletion failed")
+	}
+	
+	if rowsFound != 0 || colsFound != 0 {
+		log(pINFO, "Deleted %d rows and %d cols.\n", rowsFound, colsFound)
+	}
+
+	return nil	
+}
+
+//==============================================================================
+
+// delDupCols searches the Cols list for duplicate columns, i.e. active variables
+// whose coefficients in the constraints are proportional to those of another
+// active variable. Candidates are found by hashing the normalized column patterns,
+// so only columns with the same hash need to be compared. If the objective
+// coefficients are in the same proportion, the duplicate is merged into the
+// column that is retained, whose bounds are widened to cover both variables. 
+// Merging is only done if both variables are continuous, or if both are integer
+// and the ratio of their coefficients is 1 or -1, so the merged value can always 
+// be split back during postsolve. If the objective coefficients are not in the same
+// proportion and the retained variable is continuous and unbounded in the 
+// direction needed, the duplicate is dominated and is fixed at one of its bounds,
+// to be removed together with other fixed variables. 
+// The function passes back the number of columns merged or fixed in the numDltd
+// variable.
+// In case of failure, function returns an error.
+func delDupCols(numDltd *int) error {
+	var patterns []psPattern        // normalized pattern of each column
+	var buckets  map[uint64][]int   // columns retained so far grouped by pattern hash
+	var cost     []float64          // objective coefficient of each column
+	var rowIndex []int              // row indices of column being processed
+	var rowValue []float64          // coefficients of column being processed
+	var keep     int                // index of column retained in place of duplicate
+	var found    bool               // true if column is parallel to a retained column
+	var ratio    float64            // ratio of duplicate to retained coefficients
+	var costDiff float64            // cost of duplicate less that implied by retained column
+	var newLo    float64            // lower bound of merged column
+	var newUp    float64            // upper bound of merged column
+	var oldLo    float64            // lower bound of retained column before merging
+	var oldUp    float64            // upper bound of retained column before merging
+	var newBound float64            // bound at which dominated column is fixed
+	var canMerge bool               // true if the merged value can be split in postsolve
+	var numMrgd  int                // number of columns merged
+	var numFixed int                // number of columns fixed
+	var iel      int                // index of element being processed
+	var err      error              // error received from called functions
+
+	log(pINFO, "Looking for duplicate columns...\n")
+
+	*numDltd = 0
+
+	patterns = make([]psPattern, len(Cols))
+	cost     = make([]float64, len(Cols))
+	buckets  = make(map[uint64][]int)
+
+	for i := 0; i < len(Cols); i++ {
+
+		// Skip over locked, deleted, and fixed columns, which are removed elsewhere.
+		if Cols[i].State != stateActive || Cols[i].BndLo == Cols[i].BndUp {
+			continue
+		}
+
+		// The objective function is not part of the pattern, its coefficient
+		// is compared separately.
+		rowIndex = rowIndex[:0]
+		rowValue = rowValue[:0]
+		for j := 0; j < len(Cols[i].HasElems); j++ {
+			iel = Cols[i].HasElems[j]
+			if Elems[iel].InRow == ObjRow {
+				cost[i] = Elems[iel].Value
+				continue
+			}
+			rowIndex = append(rowIndex, Elems[iel].InRow)
+			rowValue = append(rowValue, Elems[iel].Value)
+		}
+
+		// Columns which are empty or appear only in the objective are removed elsewhere.
+		if len(rowIndex) == 0 {
+			continue
+		}
+
+		if err = makePattern(rowIndex, rowValue, 0, &patterns[i]); err != nil {
+			return errors.Wrapf(err, "delDupCols failed on column %s", Cols[i].Name)
+		}
+
+		// Compare with columns already retained that have the same hash.
+		found = false
+		for _, keep = range buckets[patterns[i].Hash] {
+			if isParallel(patterns[i], patterns[keep]) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			buckets[patterns[i].Hash] = append(buckets[patterns[i].Hash], i)
+			continue
+		}
+
+		// Column i is a multiple (ratio) of the retained column.
+		ratio    = patterns[i].Scale / patterns[keep].Scale
+		costDiff = cost[i] - ratio * cost[keep]
+		if math.Abs(costDiff) <= psParTol * (1 + math.Abs(cost[i])) {
+			costDiff = 0
+		}
+
+		canMerge = false
+		if Cols[i].Type == "R" && Cols[keep].Type == "R" {
+			canMerge = true
+		} else if Cols[i].Type != "R" && Cols[keep].Type != "R" {
+			if math.Abs(math.Abs(ratio) - 1) <= psParTol {
+				ratio    = math.Copysign(1, ratio)
+				canMerge = true
+			}
+		}
+
+		if costDiff == 0 && canMerge {
+
+			// Merge the bounds of the duplicate into those of the retained column, 
+			// reversing them if the ratio is negative.
+			oldLo = Cols[keep].BndLo
+			oldUp = Cols[keep].BndUp
+			newLo = -Plinfy
+			newUp =  Plinfy
+
+			if ratio > 0 {
+				if oldLo > -Plinfy && Cols[i].BndLo > -Plinfy {
+					newLo = oldLo + ratio * Cols[i].BndLo
+				}
+				if oldUp < Plinfy && Cols[i].BndUp < Plinfy {
+					newUp = oldUp + ratio * Cols[i].BndUp
+				}
+			} else {
+				if oldLo > -Plinfy && Cols[i].BndUp < Plinfy {
+					newLo = oldLo + ratio * Cols[i].BndUp
+				}
+				if oldUp < Plinfy && Cols[i].BndLo > -Plinfy {
+					newUp = oldUp + ratio * Cols[i].BndLo
+				}
+			} // End else ratio is negative
+
+			Cols[keep].BndLo = newLo
+			Cols[keep].BndUp = newUp
+
+			// Tag the duplicate for deletion and record which column replaced it.
+			Cols[i].State = stateDelete
+			_ = updatePsList(psopDupCol, -1, i)
+			_ = setPsRef(Cols[keep].Name, ratio, oldLo, oldUp)
+			log(pDEB, "  Col %s merged into %s.\n", Cols[i].Name, Cols[keep].Name)
+			numMrgd++
+			continue
+		} // End if columns can be merged
+
+		// The duplicate is dominated if the objective improves when its value is 
+		// moved towards one of its bounds, and the retained continuous column can 
+		// absorb the change in the constraints. Integer duplicates are fixed at 
+		// the nearest integer within their bounds.
+		if costDiff == 0 || Cols[keep].Type != "R" {
+			continue
+		}
+
+		if (costDiff > 0) == (ratio > 0) {
+			if Cols[keep].BndUp < Plinfy {
+				continue
+			}
+		} else {
+			if Cols[keep].BndLo > -Plinfy {
+				continue
+			}			
+		} // End else retained column must be able to decrease
+
+		if costDiff > 0 {
+			if Cols[i].BndLo <= -Plinfy {
+				log(pWARN, "WARNING: Dominated col %s has no lower bound, model may be unbounded.\n",
+					Cols[i].Name)
+				continue
+			}
+			newBound = Cols[i].BndLo
+			if Cols[i].Type != "R" {
+				newBound = snap(newBound, "L")
+			}
+		} else {
+			if Cols[i].BndUp >= Plinfy {
+				log(pWARN, "WARNING: Dominated col %s has no upper bound, model may be unbounded.\n",
+					Cols[i].Name)
+				continue
+			}
+			newBound = Cols[i].BndUp
+			if Cols[i].Type != "R" {
+				newBound = snap(newBound, "U")
+			}
+		} // End else fix column at upper bound
+
+		Cols[i].BndLo = newBound
+		Cols[i].BndUp = newBound
+		log(pDEB, "  Col %s dominated by %s, fixed at %f.\n", 
+			Cols[i].Name, Cols[keep].Name, newBound)
+		numFixed++
+
+	} // End for all columns
+
+	if err = delTaggedCols(numDltd); err != nil {
+		return errors.Wrap(err, "delDupCols failed")
+	}
+
+	*numDltd += numFixed
+
+	if numMrgd != 0 || numFixed != 0 {
+		log(pINFO, "Merged %d duplicate columns and fixed %d dominated columns.\n", 
+			numMrgd, numFixed)
+	}
+
+	return nil
+}
+
+//==============================================================================
+
+// rowToBounds tightens the bounds of the variable specified by colIndex using the
+// bounds of the row specified by rowIndex, in which the variable is the only one
+// and has the coefficient coef. The bounds of integer variables are rounded to the
+// nearest integer values within the new bounds.
+// In case of failure, or if the new bounds conflict and the model is infeasible,
+// function returns an error.
+func rowToBounds(rowIndex int, colIndex int, coef float64) error {
+	var newLo   float64  // lower bound implied by the row
+	var newUp   float64  // upper bound implied by the row
+	var prevBnd float64  // bound of column before it was tightened
+
+	newLo = -Plinfy
+	newUp =  Plinfy
+	
+	// A negative coefficient reverses the bounds.
+	if coef > 0 {
+		if Rows[rowIndex].RHSlo > -Plinfy {
+			newLo = Rows[rowIndex].RHSlo / coef
+		}
+		if Rows[rowIndex].RHSup < Plinfy {
+			newUp = Rows[rowIndex].RHSup / coef
+		}
+	} else {
+		if Rows[rowIndex].RHSup < Plinfy {
+			newLo = Rows[rowIndex].RHSup / coef
+		}
+		if Rows[rowIndex].RHSlo > -Plinfy {
+			newUp = Rows[rowIndex].RHSlo / coef
+		}
+	} // End else coefficient is negative
+
+	if Cols[colIndex].Type == "I" {
+		if newLo > -Plinfy {
+			newLo = snap(newLo, "L")
+		}
+		if newUp < Plinfy {
+			newUp = snap(newUp, "U")
+		}
+	}
+
+	if newLo > Cols[colIndex].BndLo {
+		prevBnd = Cols[colIndex].BndLo
+		Cols[colIndex].BndLo = newLo
+		recBndStep(colIndex, "L", prevBnd, rowIndex, "")
+	}
+	if newUp < Cols[colIndex].BndUp {
+		prevBnd = Cols[colIndex].BndUp
+		Cols[colIndex].BndUp = newUp
+		recBndStep(colIndex, "U", prevBnd, rowIndex, "")
+	}
+
+	if Cols[colIndex].BndLo > Cols[colIndex].BndUp + Featol {
+		log(pERR, "ERROR: Infeasible, row %s reverses bounds on %s.\n",
+			Rows[rowIndex].Name, Cols[colIndex].Name)
+		return psInfeasible(fmt.Sprintf("rowToBounds infeasible, bounds reversal on %s", 
+			Cols[colIndex].Name), Rows[rowIndex].Name, Cols[colIndex].Name, 
+			colBndRoots(Cols[colIndex].Name))
+	}
+
+	// Bounds within tolerance of each other are made equal, so the variable is fixed.
+	if Cols[colIndex].BndUp < Cols[colIndex].BndLo + Featol {
+		Cols[colIndex].BndUp = Cols[colIndex].BndLo
+	}
+	
+	return nil
+}
+
+//==============================================================================
+
+// delRowSingletons searches the Rows list for any singleton rows that are still
+// in the active state and deletes them. Equality rows fix the value of their
+// variable, which is removed as well, while inequality rows are converted into
+// bounds on their variable. It passes the number of rows and columns deleted back
+// in the numDltd variable.
+// In case of failure, function an error.
+func delRowSingletons(numDltd *int) error {
+	var colIndex     int  // column index of item being processed
+	var rowIndex     int  // row index of item being processed
+	var colsFound    int  // number of columns found and deleted
+	var rowsFound    int  // number of rows found and deleted
+	var coef     float64  // coefficient value
+	var newBound float64  // updated RHS value for row being processed
+	var prevLo   float64  // lower bound of column before it was fixed
+	var prevUp   float64  // upper bound of column before it was fixed
+	var err        error  // error received from secondary functions
+
+	// Initialize variables
+	*numDltd  = 0
+	rowsFound = 0
+	colsFound = 0
+
+	log(pINFO, "Looking for row singletons...\n")
+	
+	// Find all rows that contain a single variable.
+
+	for i := 0; i < len(Rows); i++ {
+
+		// Only process active rows, skip over locked and deleted ones.		
+		if Rows[i].State != stateActive {
+			continue
+		}
+		
+		if len(Rows[i].HasElems) == 1 {
+
+			colIndex = Elems[Rows[i].HasElems[0]].InCol
+			coef     = Elems[Rows[i].HasElems[0]].Value
+			//log(pTRC, "Found singleton row [%d-%s], col [%d-%s]\n",
+			//	i, Rows[i].Name, colIndex, Cols[colIndex].Name)
+
+			// Skip non-binding rows, and variables already removed with another row.
+			if Rows[i].Type == "N" || Cols[colIndex].State != stateActive {
+				continue
+			}
+
+			// Don't want any divisions by zero, so check just in case.
+			if coef == 0 {
+				log(pERR, "Error: Unexpected zero coef for Row %s, Col %s.\n",
+					Rows[i].Name, Cols[colIndex].Name)
+				return nil
+			}
+
+			if Rows[i].Type != "E" {
+				// Inequalities become bounds on the variable, and only the row
+				// is removed. The original bounds are recorded for postsolve.
+				_ = updatePsList(psopRowSnglBnd, i, colIndex)
+				if err = rowToBounds(i, colIndex, coef); err != nil {
+					return errors.Wrap(err, "delRowSingletons failed")
+				}
+				
+				Rows[i].State = stateDelete
+				log(pDEB, "  Row %s removed, bounds of col %s now %f to %f.\n", Rows[i].Name,
+					Cols[colIndex].Name, Cols[colIndex].BndLo, Cols[colIndex].BndUp)
+				continue
+			}
+
+			// The equality fixes the value of the variable.
+			newBound = Rows[i].RHSlo / coef
+			if Cols[colIndex].Type == "I" {
+				if !isInteger(newBound) {
+					log(pERR, "ERROR: Infeasible, row %s fixes integer col %s at %f.\n",
+						Rows[i].Name, Cols[colIndex].Name, newBound)
+					return psInfeasible(fmt.Sprintf("delRowSingletons infeasible, fractional value for %s",
+						Cols[colIndex].Name), Rows[i].Name, Cols[colIndex].Name, nil)
+				}
+				newBound = math.Floor(newBound + 0.5)
+			}
+
+			// The value must lie within the bounds of the variable.
+			if newBound < Cols[colIndex].BndLo - Featol || newBound > Cols[colIndex].BndUp + Featol {
+				log(pERR, "ERROR: Infeasible, row %s fixes col %s at %f outside bounds %f to %f.\n",
+					Rows[i].Name, Cols[colIndex].Name, newBound, Cols[colIndex].BndLo, 
+					Cols[colIndex].BndUp)
+				infErr := psInfeasible(fmt.Sprintf("delRowSingletons infeasible, value of %s outside its bounds",
+					Cols[colIndex].Name), Rows[i].Name, Cols[colIndex].Name, colBndRoots(Cols[colIndex].Name))
+				infErr.Chain = append(infErr.Chain, PsInfeasStep{Col: Cols[colIndex].Name, 
+					Bound: "F", Prev: newBound, Value: newBound, Row: Rows[i].Name})
+				return infErr
+			}
+
+			prevLo = Cols[colIndex].BndLo
+			prevUp = Cols[colIndex].BndUp
+			Cols[colIndex].BndLo = newBound
+			Cols[colIndex].BndUp = newBound
+			recBndStep(colIndex, "L", prevLo, i, "")
+			recBndStep(colIndex, "U", prevUp, i, "")
+
+			// Adjust the RHS of each constraint where this variable occurs
+			
+			for j := 0; j < len(Cols[colIndex].HasElems); j++ {
+				rowIndex =  Elems[Cols[colIndex].HasElems[j]].InRow
+				coef     =  Elems[Cols[colIndex].HasElems[j]].Value
+				//log(pTRC, "  j = %d, row %s\n", j, Rows[rowIndex].Name)
+				
+				if i == rowIndex {
+					//log(pDEB, "Skipping target row [%d] %s\n", i, Rows[i].Name)
+					continue
+				}
+				
+				if Rows[rowIndex].RHSlo != -Plinfy {
+					Rows[rowIndex].RHSlo -= newBound * coef
+				}
+				
+				if Rows[rowIndex].RHSup != Plinfy {
+					Rows[rowIndex].RHSup -= newBound	* coef
+				}				
+			} // End for all rows where this variable occurs
+			
+
+			// Delete row and column and update list and counters
+
+			Rows[i].State        = stateDelete
+			Cols[colIndex].State = stateDelete
+			_ = updatePsList(psopRowSingltn, i, colIndex)
+			log(pINFO, "  Row %s and col %s removed.\n", Rows[i].Name, Cols[colIndex].Name)
+			//log(pTRC, "    Row lo %f up %f, var lo %f up %f\n", Rows[i].RHSlo, Rows[i].RHSup,
+			//	Cols[colIndex].BndLo, Cols[colIndex].BndUp)
+									
+		} // End if we found singleton row
+	} // End for all rows in the list
+
+	// Delete the rows and columns, if row deletion fails return at that point,
+	// otherwise delete columns and return with the appropriate return code and
+	// number of items deleted.
+	
+	if err = delTaggedRows(&rowsFound); err != nil {
+		*numDltd = rowsFound
+		return errors.Wrap(err, "delRowSingeltons failed")	
+	}
+
+	err = delTaggedCols(&colsFound)
+	*numDltd = rowsFound + colsFound
+	if err != nil {
+		return errors.Wrap(err, "delRowSingletons failed")
+	}
+	
+	if rowsFound != 0 || colsFound != 0 {
+		log(pINFO, "Deleted %d rows and %d cols.\n", rowsFound, colsFound)
+	}
+
+	return nil
+}
+
+//==============================================================================
+// EXPORTED FUNCTIONS
+//==============================================================================
+
+// ReduceMatrix iteratively performs the reduction operations specified in the psControl 
+// structure to remove rows and columns from the model until no more reductions
+// occur, or until the maximum number of iterations is reached. The function also 
+// performs some additional reductions (e.g. removal of empty rows) which are not configurable.
+//
+// In case of failure, the function returns an error.
+//
+//	The fields of the psControl structure have the following meaning for this function:
+//	   MaxIter           int    - maximum iterations for reduction loop
+//	   DelRowNonbinding  bool   - if true, remove non-binding rows
+//	   DelRowSingleton   bool   - if true, remove row singletons
+//	   DelColSingleton   bool   - if true, remove column singletons
+//	   DelFixedVars      bool   - if true, remove fixed variables
+//	   DelDupRows        bool   - if true, remove duplicate (parallel) rows
+//	   DelDupCols        bool   - if true, merge duplicate (parallel) columns
+//	   DelForcingRows    bool   - if true, remove forcing and redundant rows
+//	   TightenCoefs      bool   - if true, tighten coefficients of MILP rows
+//	   Probe             bool   - if true, probe binary variables of MILP models once
+//	   ProbeMaxCols      int    - maximum number of columns probed, 0 for all
+//	   ProbeTimeLimit    float64 - maximum total seconds spent probing, 0 for no limit
+//	   MergeCliques      bool   - if true, extend and merge clique rows of MILP models
+//	   ScaleMethod       string - ignored by this function
+//	   VerifySoln        bool   - ignored by this function
+//	   Ranging           bool   - ignored by this function
+//	   SimplexDiag       bool   - ignored by this function
+//	   RunSolver         bool   - ignored by this function 		
+//	   FileInMps         string - ignored by this function
+//	   FileOutSoln       string - ignored by this function
+//	   FileOutMpsRdcd    string - ignored by this function
+//	   FileOutPsop       string - ignored by this function
+func ReduceMatrix(psControl PsCtrl) error {
+
+	return ReduceMatrixReport(psControl, nil)
+}
+
+//==============================================================================
+
+// ReduceMatrixReport performs the same reductions as ReduceMatrix, and returns the
+// statistics of the operations performed in psReport, which may be nil if they are
+// not needed. The fields of psControl have the meaning listed for ReduceMatrix.
+//
+// In case of failure, the function returns an error.
+func ReduceMatrixReport(psControl PsCtrl, psReport *PsReport) error {
+	var itemsFound  int  // number of items deleted by a specific operation
+	var itemsInPass int  // number of changes made in current iteration
+	var numChanges  int  // number of changes made in all iterations
+	var totalIter   int  // number of iterations performed by TightenBounds
+	var iter        int  // current iteration
+	var err       error  // error returned by secondary functions called
+
+	numChanges   = 0
+	psRunning    = true
+	psBndRowSaved = make(map[string]psRow)
+	defer func() { psRunning = false }()
+	psUnbounded  = false
+	psUnbndCol   = ""
+	psUnbndRay   = nil
+	psScaleRow   = nil
+	psScaleCol   = nil
+	psImplList   = nil
+	psCliqueList = nil
+
+	// Save the model needed by postsolve, unless it was saved by an earlier call
+	// for the same model, in which case the operations are added to the list.
+	if !psOrigSaved {
+		if err = savePsOrig(); err != nil {
+			return errors.Wrap(err, "ReduceMatrix failed")
+		}
+		psBndHist   = nil
+		psBndLoCur  = make(map[string]int)
+		psBndUpCur  = make(map[string]int)
+		psBndRowFix = make(map[string][]int)
+	}
+
+	// The report is still collected if the caller does not want it.
+	if psReport == nil {
+		psReport = &PsReport{}
+	}
+	*psReport = PsReport{StopReason: PsStopMaxIter}
+
+	numRows    := len(Rows)
+	numCols    := len(Cols)
+	numElems   := len(Elems)
+	startTime  := time.Now()
+
+	// runPass runs a single presolve pass and adds its statistics to the report.
+	// The bounds changed by passes recorded in the list of presolve operations are
+	// counted from the list, while passes changing bounds directly (bndPass) pass
+	// back the number of bounds changed. If the pass fails, the reason presolve
+	// stopped is set accordingly.
+	runPass := func(name string, bndPass bool, pass func(*int) error) error {
+		var stats PsPassStats  // statistics of the pass
+
+		stats.Name = name
+		stats.Iter = iter
+		passRows  := len(Rows)
+		passCols  := len(Cols)
+		passElems := len(Elems)
+		passOps   := len(psOpList)
+		passStart := time.Now()
+
+		passErr := pass(&itemsFound)
+
+		stats.Time    = time.Since(passStart)
+		stats.RowsDel = passRows - len(Rows)
+		stats.ColsDel = passCols - len(Cols)
+		stats.ElemDel = passElems - len(Elems)
+
+		for i := passOps; i < len(psOpList); i++ {
+			switch psOpList[i].OpType {
+			case psopBndTight:
+				stats.BndsChgd++
+			case psopCoefTight, psopCliqueExt:
+				stats.RowsChgd++
+			}
+		}
+
+		if bndPass {
+			stats.BndsChgd = itemsFound
+		}
+
+		psReport.Passes = append(psReport.Passes, stats)
+
+		if passErr != nil {
+			psReport.StopReason = PsStopError
+			if _, ok := errors.Cause(passErr).(*PsInfeasError); ok {
+				psReport.StopReason = PsStopInfeasible
+			}
+		}
+
+		return passErr
+	}
+
+	// finish completes the totals in the report. The loop counter is one past
+	// the last iteration if the maximum number of iterations was reached.
+	finish := func() {
+		psReport.Iterations = iter
+		if iter > psControl.MaxIter {
+			psReport.Iterations = psControl.MaxIter
+		}
+		psReport.RowsDel    = numRows - len(Rows)
+		psReport.ColsDel    = numCols - len(Cols)
+		psReport.ElemDel    = numElems - len(Elems)
+		psReport.Time       = time.Since(startTime)
+
+		passIndex := make(map[string]int)
+
+		for i := 0; i < len(psReport.Passes); i++ {
+			psReport.BndsChgd += psReport.Passes[i].BndsChgd
+			psReport.RowsChgd += psReport.Passes[i].RowsChgd
+
+			j, ok := passIndex[psReport.Passes[i].Name]
+			if !ok {
+				j = len(psReport.PassTotals)
+				passIndex[psReport.Passes[i].Name] = j
+				psReport.PassTotals = append(psReport.PassTotals, 
+					PsPassStats{Name: psReport.Passes[i].Name})
+			}
+
+			psReport.PassTotals[j].RowsDel  += psReport.Passes[i].RowsDel
+			psReport.PassTotals[j].ColsDel  += psReport.Passes[i].ColsDel
+			psReport.PassTotals[j].ElemDel  += psReport.Passes[i].ElemDel
+			psReport.PassTotals[j].BndsChgd += psReport.Passes[i].BndsChgd
+			psReport.PassTotals[j].RowsChgd += psReport.Passes[i].RowsChgd
+			psReport.PassTotals[j].Time     += psReport.Passes[i].Time
+		} // End for all passes
+	}
+
+	defer finish()
+
+	for iter = 1; iter <= psControl.MaxIter; iter++ {
+
+		// Iterate over row and column reductions until no more changes in the
+		// number of elements are observed. The NumElements global counter is 
+		// updated whenever rows or columns are deleted.
+		
+		itemsInPass = 0
 		
-		Cols[i].State = stateDelete
-		Rows[rowIndex].State = stateDelete
-		_ = updatePsList(psopFreeCol, rowIndex, i)
+		log(pINFO, "\nIteration %d: %d rows, %d cols, %d elements.\n", iter,
+			len(Rows), len(Cols), len(Elems))
+
+		if psControl.DelRowNonbinding {
+
+			err = runPass(PsPassTightenBounds, false, func(numFound *int) error {
+				*numFound = 0
+				return TightenBounds(psControl.MaxIter, &totalIter)
+			})
+			if err != nil {
+				return errors.Wrap(err, "TightenBounds failed")		
+			}
+			
+			if err = runPass(PsPassNbRows, false, delNbRows); err != nil {
+				numChanges += itemsFound
+				return errors.Wrap(err, "ReduceMatrix failed")				
+			}
+					
+			itemsInPass += itemsFound
+		} // End if non-binding row
+
+
+		// Probing visits every binary column, so it is done in the first
+		// iteration only, which also bounds the total time spent probing by
+		// ProbeTimeLimit.
+		if psControl.Probe && iter == 1 {
+			err = runPass(PsPassProbe, true, func(numFound *int) error {
+				return probeBinaries(psControl.ProbeMaxCols, psControl.ProbeTimeLimit, numFound)
+			})
+			if err != nil {
+				numChanges += itemsFound
+				return errors.Wrap(err, "ReduceMatrix failed")
+			}
+
+			itemsInPass += itemsFound
+		} // End if probing
+
+
+		if psControl.MergeCliques {
+			if err = runPass(PsPassCliques, false, mergeCliques); err != nil {
+				numChanges += itemsFound
+				return errors.Wrap(err, "ReduceMatrix failed")
+			}
+
+			itemsInPass += itemsFound
+		} // End if cliques
+
+
+		if psControl.DelFixedVars || psControl.DelRowNonbinding || psControl.DelDupCols ||
+			psControl.DelForcingRows || psControl.Probe || psControl.MergeCliques {
+			// This component must be executed if non-binding rows were removed,
+			// or if other operations fixed variables at one of their bounds.
+			if err = runPass(PsPassFixedVars, false, delFixedVars); err != nil {
+				numChanges += itemsFound
+				return errors.Wrap(err, "ReduceMatrix failed")
+			}
+
+			itemsInPass += itemsFound
+		} // End if fixed variable
+
+
+		if psControl.DelDupRows {
+			if err = runPass(PsPassDupRows, false, delDupRows); err != nil {
+				numChanges += itemsFound
+				return errors.Wrap(err, "ReduceMatrix failed")
+			}
+
+			itemsInPass += itemsFound
+		} // End if duplicate rows
+
+
+		if psControl.DelForcingRows {
+			if err = runPass(PsPassForcingRows, false, delForcingRows); err != nil {
+				numChanges += itemsFound
+				return errors.Wrap(err, "ReduceMatrix failed")
+			}
+
+			itemsInPass += itemsFound
+		} // End if forcing rows
+
+
+		if psControl.TightenCoefs {
+			if err = runPass(PsPassCoefs, false, tightenCoefs); err != nil {
+				numChanges += itemsFound
+				return errors.Wrap(err, "ReduceMatrix failed")
+			}
+
+			itemsInPass += itemsFound
+		} // End if coefficient tightening
+
+
+		if psControl.DelDupCols {
+			if err = runPass(PsPassDupCols, false, delDupCols); err != nil {
+				numChanges += itemsFound
+				return errors.Wrap(err, "ReduceMatrix failed")
+			}
+
+			itemsInPass += itemsFound
+		} // End if duplicate columns
+
+
+		if psControl.DelRowSingleton {
+			if err = runPass(PsPassRowSingletons, false, delRowSingletons); err != nil {
+				numChanges += itemsFound
+				return errors.Wrap(err, "ReduceMatrix failed")							
+			}
+			
+			itemsInPass += itemsFound			
+		} // End if row singleton	
+
 						
-	} // End for all columns	
+		if psControl.DelColSingleton {
+			if err = runPass(PsPassColSingletons, false, delFreeColSingls); err != nil {
+				numChanges += itemsFound
+				return errors.Wrap(err, "ReduceMatrix failed")								
+			}
+			
+			itemsInPass += itemsFound						
+		} // End if column singleton
+
+		// Empty rows are deleted automatically without any configurable flag.	
+		if err = runPass(PsPassEmptyRows, false, delEmptyRows); err != nil {
+			numChanges += itemsFound
+			return errors.Wrap(err, "ReduceMatrix failed")											
+		}
+
+		itemsInPass += itemsFound
+
+		// Empty cols are deleted automatically without any configurable flag.	
+		if err = runPass(PsPassEmptyCols, false, delEmptyCols); err != nil {
+			numChanges += itemsFound
+			return errors.Wrap(err, "ReduceMatrix failed")											
+		}
+
+		// Increment counters and print status when done.
+		itemsInPass += itemsFound		
+		numChanges  += itemsInPass
+				
+		if itemsInPass == 0 {
+			log(pINFO, "Reduction done after %d of %d iterations, %d items removed.\n", 
+					iter, psControl.MaxIter, numChanges)
+			psReport.StopReason = PsStopNoChange
+			break
+		}
+
+	} // End for maximum iterations loop
+
+	// Columns which can improve the objective without limit are left in the
+	// model, so they only need to be found once.
+	if err = findUnbndCols(&itemsFound); err != nil {
+		return errors.Wrap(err, "ReduceMatrix failed")
+	}
+
+	return nil
+}
+
+//==============================================================================
+
+// PrintPsReport prints the presolve report passed to the function via the
+// psReport parameter. The totals of each pass over all iterations are printed,
+// followed by the totals over all passes.
+// In case of failure, function returns an error.
+func PrintPsReport(psReport PsReport) error {
+
+	fmt.Printf("\nPRESOLVE REPORT\n\n")
+	fmt.Printf("%d ITERATIONS, stopped by %s\n", psReport.Iterations, psReport.StopReason)
+	fmt.Printf("%-16s %8s %8s %8s %8s %8s %12s\n", "Pass", "RowsDel", "ColsDel", 
+		"ElemDel", "BndsChgd", "RowsChgd", "Time")
+
+	for i := 0; i < len(psReport.PassTotals); i++ {
+		pass := psReport.PassTotals[i]
+		fmt.Printf("%-16s %8d %8d %8d %8d %8d %12s\n", pass.Name, pass.RowsDel, 
+			pass.ColsDel, pass.ElemDel, pass.BndsChgd, pass.RowsChgd, pass.Time)
+	}
+
+	fmt.Printf("%-16s %8d %8d %8d %8d %8d %12s\n", "Total", psReport.RowsDel, 
+		psReport.ColsDel, psReport.ElemDel, psReport.BndsChgd, psReport.RowsChgd, 
+		psReport.Time)
+	
+	fmt.Printf("\n")
+	
+	return nil
+}
+
+//==============================================================================
+
+// WritePsopFile writes the rows and columns that were removed during the pre-solve
+// operations to a text file specified by the user. The function accepts two
+// arguments, fileName and coefPerLine. If the file name the file to which the
+// output is written, and if empty, a default name is used. 
+//
+// The coefPerLine specifies how many coefficient name/value pairs should be 
+// written per line and is interpretted as follows:
+//	  < 0 - all pairs are written on a single line (no CR/LF is inserted between pairs)
+//	    0 - printing of coefficient name/value pairs is suppressed
+//	    n - a carriage return line feed is inserted after printing n pairs  
+//
+// All values are written with full precision, so that a file written with
+// coefficients can be read back by ReadPsopFile without loss of information.
+// If the reduced model was scaled before it was sent to the solver, the scale
+// factors are written too, so that its solution can be unscaled by PostSolve.
+// In case of failure, the function returns an error.
+func WritePsopFile(fileName string, coefPerLine int) error {
+
+	var opName       string // operation name in more detail than internal var. 
+	var printCoef    bool   // controls if coef name/value pairs are printed
+	var coefCrNeeded bool   // controls if <CR> printed between coef name/value pairs
+
+
+	//Check whether the file exists. If it exists, overwrite it.
+
+	if _, err := os.Stat(fileName); err == nil {
+		err = os.Remove(fileName)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to delete existing file %s", fileName)
+		}
+	}
+	
+	f, err := os.Create(fileName)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to create new file %s", fileName)
+	}
+
+ 	defer f.Close()
+
+	// Set flags to control printing of coefficient name/value pairs per line
+	printCoef    = true
+	coefCrNeeded = true
+	
+	if coefPerLine < 0	{
+		coefCrNeeded = false
+		coefPerLine *= -1
+	} else if coefPerLine == 0 {
+		printCoef = false
+	}
+
+	// writeCoefs prints the coefficient name/value pairs of a row or column.
+	writeCoefs := func(coefList []psCoef) {
+		var index int  // index tracking how many coefficients were printed
+
+		if !printCoef {
+			return
+		}
+
+		for index = 0; index < len(coefList); index++ {
+			fmt.Fprintf(f, "%15s %24s", coefList[index].Name, fmtPsFloat(coefList[index].Value))
+
+			if coefCrNeeded && ((index + 1) % coefPerLine) == 0 {
+				fmt.Fprintf(f, "\n")
+			}				
+		} // End for list of coefficients
+
+		// Print extra CR if the last line was not complete.
+		if index != 0 && (!coefCrNeeded || (index % coefPerLine) != 0) {
+			fmt.Fprintf(f, "\n")				
+		}
+	}
+
+	// Print status message to screen and general header into the file.
+	log(pINFO, "\nWriting pre-solve operations to file %s.\n", fileName)
+
+	startTime := time.Now()
+
+	fmt.Fprintf(f, "%s", fileDelim)
+	fmt.Fprintf(f, "# LPO record of pre-solve operations\n")	
+	fmt.Fprintf(f, "# Problem name: %s\n", Name)
+	fmt.Fprintf(f, "# Created on:   %s\n", startTime.Format("2006-01-02 15:04:05"))
+	fmt.Fprintf(f, "#\n# Obj format:   OBJ:  Name  Constant  ScaleFactor  NumCoef\n")
+	fmt.Fprintf(f, "# Org format:   ORG:  Name  Type  Rhs  LowerBound  UpperBound  ScaleFactor\n")
+	fmt.Fprintf(f, "# Col format:   COL:  Name  Type  LowerBound  UpperBound  Cost  ScaleFactor  NumCoef\n")
+	fmt.Fprintf(f, "# Row format:   ROW:  Name  Type  Rhs  LowerBound  UpperBound  ScaleFactor  NumCoef\n")
+	fmt.Fprintf(f, "# Ref format:   REF:  RetainedName  Ratio  LowerBound  UpperBound  AtBound\n")
+	fmt.Fprintf(f, "# Scale format: SCALE:  ROW|COL  Name  ScaleFactor\n")
+	
+	if printCoef {
+		fmt.Fprintf(f, "# Followed by:  CoefName CoefValue (up to %d pairs/line)\n#\n", coefPerLine)
+	} else {
+		fmt.Fprintf(f, "# Coefficient name/value pairs for rows are not printed.\n#\n")
+	}
+
+	fmt.Fprintf(f, "PSOPVER: %d %t\n", psopFileVersion, printCoef)
+	fmt.Fprintf(f, "UNBOUNDED: %t\n", psUnbounded)
+
+	// Print the scale factors applied to the reduced model before it was sent to
+	// the solver, which are needed to unscale its solution.
+	writeScale := func(kind string, scaleMap map[string]float64) {
+		var names []string  // names of the rows or columns scaled
+
+		for name := range scaleMap {
+			names = append(names, name)
+		}
+		sort.Strings(names)
 
-	// Delete the rows and columns, if row deletion fails return at that point,
-	// otherwise delete columns and return with the appropriate return code and
-	// number of items deleted.
-	
-	if err = delTaggedRows(&rowsFound); err != nil {
-		*numDltd = rowsFound
-		return errors.Wrap(err, "delFreeColSingls row deletion failed")	
+		for _, name := range names {
+			fmt.Fprintf(f, "SCALE:  %s %s   %s\n", kind, name, fmtPsFloat(scaleMap[name]))
+		}
 	}
-	
-	err = delTaggedCols(&colsFound)
-	*numDltd = rowsFound + colsFound
-	if err != nil {
-		return errors.Wrap(err, "delFreeColSingls col deletion failed")
+
+	if len(psScaleRow) > 0 || len(psScaleCol) > 0 {
+		fmt.Fprintf(f, "%s", fileDelim)
+		fmt.Fprintf(f, "# Scale factors applied to the reduced model\n")
+		writeScale("ROW", psScaleRow)
+		writeScale("COL", psScaleCol)
 	}
-	
-	if rowsFound != 0 || colsFound != 0 {
-		log(pINFO, "Deleted %d rows and %d cols.\n", rowsFound, colsFound)
+
+	// Print the objective function and the rows of the model before presolve,
+	// which are needed to complete the solution during postsolve.
+	fmt.Fprintf(f, "%s", fileDelim)
+	fmt.Fprintf(f, "# Model before pre-solve operations\n")
+	fmt.Fprintf(f, "OBJ:  %s   %s %s %d\n", fmtPsName(psOrigObj.Name), 
+		fmtPsFloat(psOrigConst), fmtPsFloat(psOrigObj.ScaleFactor), len(psOrigObj.Coef))
+	writeCoefs(psOrigObj.Coef)
+
+	for i := 0; i < len(psOrigRows); i++ {
+		fmt.Fprintf(f, "ORG:  %s   %s %s %s %s %s\n", psOrigRows[i].Name, psOrigRows[i].Type,
+			fmtPsFloat(psOrigRows[i].Rhs), fmtPsFloat(psOrigRows[i].RhsLo),
+			fmtPsFloat(psOrigRows[i].RhsUp), fmtPsFloat(psOrigRows[i].ScaleFactor))
 	}
 
-	return nil	
+	// Print the rows and cols associated with each PSOP in the list.
+	for i := 0; i < len(psOpList); i++ {
+
+		// Set the name based on operation type being processed.
+		switch psOpList[i].OpType {
+			
+			case psopEmptyRow:
+				opName     = "Empty Row"
+							
+			case psopEmptyCol:
+				opName     = "Empty Column"
+
+			case psopFixedVar:
+				opName     = "Fixed Variable"
+			
+			case psopFreeCol:
+				opName     = "Free Column Singleton"
+
+			case psopImplFreeCol:
+				opName     = "Implied Free Column Singleton"
+
+			case psopCostFreeCol:
+				opName     = "Free Column Singleton With Cost"
+			
+			case psopNbRow:
+				opName     = "Non-binding Row"
+			
+			case psopRowSingltn:
+				opName     = "Row Singleton"
+
+			case psopRowSnglBnd:
+				opName     = "Row Singleton Bound"
+
+			case psopDupRow:
+				opName     = "Duplicate Row"
+
+			case psopDupCol:
+				opName     = "Duplicate Column"
+
+			case psopForcingRow:
+				opName     = "Forcing Row"
+
+			case psopRedRow:
+				opName     = "Redundant Row"
+
+			case psopCoefTight:
+				opName     = "Coefficient Tightening"
+
+			case psopCliqueExt:
+				opName     = "Clique Row Extension"
+
+			case psopBndTight:
+				opName     = "Bound Tightened by Row"
+			
+			default:
+				opName     = "Unknown Operation"
+						
+		} // End switch on operation type
+
+		// Print the operation type, ID
+		fmt.Fprintf(f, "%s", fileDelim)
+		fmt.Fprintf(f, "# %s\n", opName)		
+		fmt.Fprintf(f, "PSOP: %s %5d\n", psOpList[i].OpType, i)
+
+		if psOpList[i].Ref != "" || psOpList[i].AtBound != "" || psOpList[i].Ratio != 0 ||
+			psOpList[i].BndLo != 0 || psOpList[i].BndUp != 0 {
+			fmt.Fprintf(f, "REF:  %s   %s %s %s %s\n", fmtPsName(psOpList[i].Ref), 
+				fmtPsFloat(psOpList[i].Ratio), fmtPsFloat(psOpList[i].BndLo), 
+				fmtPsFloat(psOpList[i].BndUp), fmtPsName(psOpList[i].AtBound))
+		} // End if retained item was recorded
+
+		if psOpList[i].Col.Name != "" {
+			fmt.Fprintf(f, "COL:  %s   %s %s %s %s %s %d\n", 
+				psOpList[i].Col.Name, psOpList[i].Col.Type,
+				fmtPsFloat(psOpList[i].Col.BndLo), fmtPsFloat(psOpList[i].Col.BndUp), 
+				fmtPsFloat(psOpList[i].Col.Cost), fmtPsFloat(psOpList[i].Col.ScaleFactor),
+				len(psOpList[i].Col.Coef))
+			writeCoefs(psOpList[i].Col.Coef)
+		} // End if column was printed		
+		
+		if psOpList[i].Row.Name != "" {
+			fmt.Fprintf(f, "ROW:  %s   %s %s %s %s %s %d\n", 
+				psOpList[i].Row.Name, psOpList[i].Row.Type, 
+				fmtPsFloat(psOpList[i].Row.Rhs), fmtPsFloat(psOpList[i].Row.RhsLo),
+				fmtPsFloat(psOpList[i].Row.RhsUp), fmtPsFloat(psOpList[i].Row.ScaleFactor),
+				len(psOpList[i].Row.Coef))
+			writeCoefs(psOpList[i].Row.Coef)
+		} // End if row was printed				
+	} // End for processing post-solve operations list
+
+	log(pINFO, "Successfully wrote %d operations.\n", len(psOpList))
+			
+	return nil
 }
 
 //==============================================================================
 
-// delRowSingletons searches the Rows list for any singleton rows that are still
-// in the active state and deletes them. It passes the number of rows deleted back
-// in the numDltd variable.
-// In case of failure, function an error.
-func delRowSingletons(numDltd *int) error {
-	var colIndex     int  // column index of item being processed
-	var rowIndex     int  // row index of item being processed
-	var colsFound    int  // number of columns found and deleted
-	var rowsFound    int  // number of rows found and deleted
-	var coef     float64  // coefficient value
-	var newBound float64  // updated RHS value for row being processed
-	var err        error  // error received from secondary functions
+// ReadPsopFile reads the list of pre-solve operations from a file written by 
+// WritePsopFile with coefficients included, replacing the operations recorded
+// by ReduceMatrix, if any. Together with PostSolve, it allows the solution of a
+// reduced model solved by another process to be completed to a solution of the
+// original model. Loading a model clears the operations, so the file must be read
+// after the model, if any, is loaded. The function accepts the name of the file
+// to be read. 
+// In case of failure, the function returns an error.
+func ReadPsopFile(fileName string) error {
+	var opList  []psOp     // list of operations read from the file
+	var origObj psRow      // objective function before presolve
+	var origRows []psRow   // rows before presolve
+	var origConst float64  // objective function constant before presolve
+	var unbounded bool     // flag indicating presolve found the model unbounded
+	var scaleRow  map[string]float64  // row scale factors applied to the reduced model
+	var scaleCol  map[string]float64  // column scale factors applied to the reduced model
+	var coefList *[]psCoef // list to which coefficients being read are added
+	var numCoef   int      // number of coefficients still to be read
+	var version   int      // version of the file format
+	var lineNum   int      // number of the line being processed
+	var token  []string    // tokens of the line being processed
+	var value  [4]float64  // numerical values of the line being processed
+	var err       error    // error returned by secondary functions called
+
+	psopFile, err := os.Open(fileName)
+	if err != nil {
+		return errors.Wrapf(err, "ReadPsopFile failed to open file %s", fileName)
+	}
 
-	// Initialize variables
-	*numDltd  = 0
-	rowsFound = 0
-	colsFound = 0
+	log(pINFO, "\nReading pre-solve operations from file %s.\n", fileName)
+	defer psopFile.Close()
+	psopReader := bufio.NewReader(psopFile)
 
-	log(pINFO, "Looking for row singletons...\n")
-	
-	// Find all rows that contain a single variable.
+	// parseValues converts the tokens starting at index first to numerical values.
+	parseValues := func(first int, num int) error {
+		if len(token) < first + num {
+			return errors.Errorf("too few fields in line %d", lineNum)
+		}
+		for k := 0; k < num; k++ {
+			if value[k], err = strconv.ParseFloat(token[first + k], 64); err != nil {
+				return errors.Wrapf(err, "invalid value in line %d", lineNum)
+			}
+		}
+		return nil
+	}
 
-	for i := 0; i < len(Rows); i++ {
+	// startCoefs sets the list to which the coefficients that follow are added.
+	startCoefs := func(list *[]psCoef, numField string) error {
+		if numCoef, err = strconv.Atoi(numField); err != nil {
+			return errors.Wrapf(err, "invalid number of coefficients in line %d", lineNum)
+		}
+		coefList = list
+		return nil
+	}
 
-		// Only process active rows, skip over locked and deleted ones.		
-		if Rows[i].State != stateActive {
+	for {
+		lineNum++
+		curLine, readErr := psopReader.ReadString('\n')
+
+		if readErr != nil && readErr != io.EOF {
+			return errors.Wrapf(readErr, "ReadPsopFile failed in line %d", lineNum)
+		}
+
+		token = strings.Fields(curLine)
+
+		// Skip blank lines and comments.
+		if len(token) == 0 || strings.HasPrefix(token[0], "#") {
+			if readErr == io.EOF {
+				break
+			}
 			continue
 		}
-		
-		if len(Rows[i].HasElems) == 1 {
 
+		// Lines of coefficient name/value pairs follow the row or column they
+		// belong to.
+		if numCoef > 0 {
+			if len(token) % 2 != 0 || len(token) / 2 > numCoef {
+				return errors.Errorf("ReadPsopFile found invalid coefficients in line %d", lineNum)
+			}
+			for k := 0; k < len(token); k += 2 {
+				coefValue, err := strconv.ParseFloat(token[k + 1], 64)
+				if err != nil {
+					return errors.Wrapf(err, "ReadPsopFile found invalid value in line %d", lineNum)
+				}
+				*coefList = append(*coefList, psCoef{Name: token[k], Value: coefValue})
+				numCoef--
+			}
+			if readErr == io.EOF {
+				break
+			}
+			continue
+		} // End if coefficients expected
 
-			if Rows[i].Type != "E" {
-				// Skip any inequalities.
-				continue
+		if version == 0 && token[0] != "PSOPVER:" {
+			return errors.Errorf("ReadPsopFile found no version in file %s", fileName)
+		}
+
+		switch token[0] {
+
+		case "PSOPVER:":
+			if len(token) < 3 {
+				return errors.Errorf("ReadPsopFile found invalid version in line %d", lineNum)
+			}
+			if version, err = strconv.Atoi(token[1]); err != nil || version != psopFileVersion {
+				return errors.Errorf("ReadPsopFile unable to read version %s", token[1])
+			}
+			if token[2] != "true" {
+				return errors.Errorf("ReadPsopFile found file %s without coefficients", fileName)
 			}
-			
-			colIndex = Elems[Rows[i].HasElems[0]].InCol
-			coef     = Elems[Rows[i].HasElems[0]].Value
-			//log(pTRC, "Found singleton row [%d-%s], col [%d-%s]\n",
-			//	i, Rows[i].Name, colIndex, Cols[colIndex].Name)
 
-			// Don't want any divisions by zero, so check just in case.
-			if coef == 0 {
-				log(pERR, "Error: Unexpected zero coef for Row %s, Col %s.\n",
-					Rows[i].Name, Cols[colIndex].Name)
-				return nil
+		case "UNBOUNDED:":
+			unbounded = len(token) > 1 && token[1] == "true"
+
+		case "SCALE:":
+			if err = parseValues(3, 1); err != nil {
+				return errors.Wrap(err, "ReadPsopFile failed")
+			}
+			switch token[1] {
+			case "ROW":
+				if scaleRow == nil {
+					scaleRow = make(map[string]float64)
+				}
+				scaleRow[token[2]] = value[0]
+			case "COL":
+				if scaleCol == nil {
+					scaleCol = make(map[string]float64)
+				}
+				scaleCol[token[2]] = value[0]
+			default:
+				return errors.Errorf("ReadPsopFile found invalid scale type %s in line %d",
+					token[1], lineNum)
 			}
 
-			// Set the variable bounds depending on the row type.
-			switch Rows[i].Type {
+		case "OBJ:":
+			if err = parseValues(2, 2); err != nil {
+				return errors.Wrap(err, "ReadPsopFile failed")
+			}
+			if len(token) < 5 {
+				return errors.Errorf("ReadPsopFile found too few fields in line %d", lineNum)
+			}
+			origObj   = psRow{Name: parsePsName(token[1]), Type: "N", ScaleFactor: value[1]}
+			origConst = value[0]
+			if err = startCoefs(&origObj.Coef, token[4]); err != nil {
+				return errors.Wrap(err, "ReadPsopFile failed")
+			}
 
-			// TODO: Andersen algorithm may only work for equality constraints, and
-			// the other cases can be removed. For now, leave them in until confirmed.
-			case "G":
-				newBound = Rows[i].RHSlo / coef
-				Cols[colIndex].BndLo = newBound
+		case "ORG:":
+			if err = parseValues(3, 4); err != nil {
+				return errors.Wrap(err, "ReadPsopFile failed")
+			}
+			origRows = append(origRows, psRow{Name: token[1], Type: token[2], Rhs: value[0],
+				RhsLo: value[1], RhsUp: value[2], ScaleFactor: value[3]})
 
-			case "L":
-				newBound = Rows[i].RHSup / coef
-				Cols[colIndex].BndUp = newBound
+		case "PSOP:":
+			if len(token) < 2 {
+				return errors.Errorf("ReadPsopFile found too few fields in line %d", lineNum)
+			}
+			opList = append(opList, psOp{OpType: token[1]})
 
-			case "E", "N":
-				newBound = Rows[i].RHSlo / coef
-				Cols[colIndex].BndLo = newBound
-				Cols[colIndex].BndUp = newBound
+		case "REF:", "COL:", "ROW:":
+			if len(opList) == 0 {
+				return errors.Errorf("ReadPsopFile found %s before PSOP in line %d", 
+					token[0], lineNum)
+			}
+			op := &opList[len(opList) - 1]
 
-			} // End switch on row type
+			switch token[0] {
+			case "REF:":
+				if err = parseValues(2, 3); err != nil {
+					return errors.Wrap(err, "ReadPsopFile failed")
+				}
+				if len(token) < 6 {
+					return errors.Errorf("ReadPsopFile found too few fields in line %d", lineNum)
+				}
+				op.Ref     = parsePsName(token[1])
+				op.Ratio   = value[0]
+				op.BndLo   = value[1]
+				op.BndUp   = value[2]
+				op.AtBound = parsePsName(token[5])
+
+			case "COL:":
+				if err = parseValues(3, 4); err != nil {
+					return errors.Wrap(err, "ReadPsopFile failed")
+				}
+				if len(token) < 8 {
+					return errors.Errorf("ReadPsopFile found too few fields in line %d", lineNum)
+				}
+				op.Col = psCol{Name: token[1], Type: token[2], BndLo: value[0], 
+					BndUp: value[1], Cost: value[2], ScaleFactor: value[3]}
+				if err = startCoefs(&op.Col.Coef, token[7]); err != nil {
+					return errors.Wrap(err, "ReadPsopFile failed")
+				}
 
-			// Adjust the RHS of each constraint where this variable occurs
-			
-			for j := 0; j < len(Cols[colIndex].HasElems); j++ {
-				rowIndex =  Elems[Cols[colIndex].HasElems[j]].InRow
-				coef     =  Elems[Cols[colIndex].HasElems[j]].Value
-				//log(pTRC, "  j = %d, row %s\n", j, Rows[rowIndex].Name)
-				
-				if i == rowIndex {
-					//log(pDEB, "Skipping target row [%d] %s\n", i, Rows[i].Name)
-					continue
+			case "ROW:":
+				if err = parseValues(3, 4); err != nil {
+					return errors.Wrap(err, "ReadPsopFile failed")
 				}
-				
-				if Rows[rowIndex].RHSlo != -Plinfy {
-					Rows[rowIndex].RHSlo -= newBound * coef
+				if len(token) < 8 {
+					return errors.Errorf("ReadPsopFile found too few fields in line %d", lineNum)
 				}
-				
-				if Rows[rowIndex].RHSup != Plinfy {
-					Rows[rowIndex].RHSup -= newBound	* coef
-				}				
-			} // End for all rows where this variable occurs
-			
+				op.Row = psRow{Name: token[1], Type: token[2], Rhs: value[0], 
+					RhsLo: value[1], RhsUp: value[2], ScaleFactor: value[3]}
+				if err = startCoefs(&op.Row.Coef, token[7]); err != nil {
+					return errors.Wrap(err, "ReadPsopFile failed")
+				}
+			} // End switch on item of operation
 
-			// Delete row and column and update list and counters
+		default:
+			return errors.Errorf("ReadPsopFile found unknown keyword %s in line %d", 
+				token[0], lineNum)
 
-			Rows[i].State        = stateDelete
-			Cols[colIndex].State = stateDelete
-			_ = updatePsList(psopRowSingltn, i, colIndex)
-			log(pINFO, "  Row %s and col %s removed.\n", Rows[i].Name, Cols[colIndex].Name)
-			//log(pTRC, "    Row lo %f up %f, var lo %f up %f\n", Rows[i].RHSlo, Rows[i].RHSup,
-			//	Cols[colIndex].BndLo, Cols[colIndex].BndUp)
-									
-		} // End if we found singleton row
-	} // End for all rows in the list
+		} // End switch on keyword
 
-	// Delete the rows and columns, if row deletion fails return at that point,
-	// otherwise delete columns and return with the appropriate return code and
-	// number of items deleted.
-	
-	if err = delTaggedRows(&rowsFound); err != nil {
-		*numDltd = rowsFound
-		return errors.Wrap(err, "delRowSingeltons failed")	
+		if readErr == io.EOF {
+			break
+		}
+	} // End for all lines in file
+
+	if version == 0 {
+		return errors.Errorf("ReadPsopFile found no version in file %s", fileName)
+	}
+	if numCoef > 0 {
+		return errors.Errorf("ReadPsopFile found %d coefficients missing at end of file", numCoef)
 	}
 
-	err = delTaggedCols(&colsFound)
-	*numDltd = rowsFound + colsFound
-	if err != nil {
-		return errors.Wrap(err, "delRowSingletons failed")
+	// The file was read successfully, so replace the current operations.
+	psOpList    = opList
+	psOrigSaved = true
+	psOrigObj   = origObj
+	psOrigRows  = origRows
+	psOrigCols  = nil
+	psOrigConst = origConst
+	psUnbounded = unbounded
+	psScaleRow  = scaleRow
+	psScaleCol  = scaleCol
+
+	log(pINFO, "Successfully read %d operations.\n", len(psOpList))
+
+	return nil
+}
+
+//==============================================================================
+
+// PostSolve completes the solution of a reduced model, passed to the function as
+// the constraint and variable maps (conMap, varMap) keyed by row and column name,
+// to a solution of the original model using the list of pre-solve operations
+// recorded by ReduceMatrix or read by ReadPsopFile. The maps passed in are not
+// modified. The complete solution is returned in psRslt, with the original types
+// and RHS of all rows restored and the objective function value calculated from
+// the original objective function. The numbers of rows and columns removed are
+// those that are not present in the maps passed in; the number of elements removed
+// and the presolve report are not available and are set to zero.
+// In case of failure, the function returns an error.
+func PostSolve(conMap PsResConMap, varMap PsResVarMap, psRslt *PsSoln) error {
+	var err error  // error returned by secondary functions called
+
+	*psRslt = PsSoln{}
+	psRslt.ConMap    = make(PsResConMap)
+	psRslt.VarMap    = make(PsResVarMap)
+	psRslt.Unbounded = psUnbounded
+
+	for name, mapItem := range conMap {
+		psRslt.ConMap[name] = mapItem
 	}
-	
-	if rowsFound != 0 || colsFound != 0 {
-		log(pINFO, "Deleted %d rows and %d cols.\n", rowsFound, colsFound)
+
+	for name, mapItem := range varMap {
+		psRslt.VarMap[name] = mapItem
+	}
+
+	// Undo the scaling of the reduced model, if any.
+	unscaleSoln(psRslt.ConMap, psRslt.VarMap)
+
+	// Update the maps with the information deleted during presolve.
+	if err = postSolve(psRslt.ConMap, psRslt.VarMap); err != nil {
+		return errors.Wrap(err, "PostSolve failed")
+	}
+
+	// Restore the original type and RHS of all rows, and add any rows that were
+	// not put back during postsolve.
+	for i := 0; i < len(psOrigRows); i++ {
+		if _, ok := conMap[psOrigRows[i].Name]; !ok && psOrigRows[i].Name != psOrigObj.Name {
+			psRslt.RowsDel++
+		}
+		_ = addConMapItem(psRslt.ConMap, psOrigRows[i])
+	}
+
+	psRslt.ColsDel = len(psRslt.VarMap) - len(varMap)
+
+	// Complete the ray found by presolve if the model was found unbounded.
+	if psUnbndRay != nil {
+		psRslt.Ray = make(map[string]float64)
+		if err = PostSolveRay(psUnbndRay, psRslt.Ray); err != nil {
+			return errors.Wrap(err, "PostSolve failed")
+		}
+	}
+
+	// Calculate the value of the objective function including its constant.
+	if err = getPstLhs(psOrigObj, psRslt.VarMap, &psRslt.ObjVal); err != nil {
+		return errors.Wrap(err, "PostSolve failed")		
+	}
+
+	psRslt.ObjVal -= psOrigConst
+
+	return nil
+}
+
+//==============================================================================
+
+// PostSolveRay completes a primal ray of the reduced model (ray), keyed by column
+// name, to a primal ray of the original model using the list of pre-solve
+// operations recorded by ReduceMatrix or read by ReadPsopFile, and adds it to the
+// map passed to the function (origRay). Columns missing from the ray are taken to
+// be zero. Columns removed by presolve are added to origRay. Columns fixed by
+// presolve do not change along the ray, columns removed with a row are changed so
+// that the activity of the row does not change, and the ray of a merged duplicate
+// column is split between the columns so that both stay within their bounds. The
+// ray is that of the LP relaxation; coefficients tightened for MILP models are not
+// taken into account.
+// In case of failure, the function returns an error.
+func PostSolveRay(ray map[string]float64, origRay map[string]float64) error {
+	var coef   float64  // coefficient of the column being recovered
+	var change float64  // change of row activity along the ray
+	var mrgVal float64  // ray of merged column
+
+	for name, value := range ray {
+		origRay[name] = value
 	}
 
+	for i := len(psOpList) - 1; i >= 0; i-- {
+
+		switch psOpList[i].OpType {
+
+		// Columns fixed at a value do not change along the ray.
+		case psopFixedVar, psopRowSingltn, psopEmptyCol:
+			origRay[psOpList[i].Col.Name] = 0
+
+		// The column removed with its row keeps the activity of the row unchanged.
+		case psopFreeCol, psopImplFreeCol, psopCostFreeCol:
+			coef   = 0
+			change = 0
+			for j := 0; j < len(psOpList[i].Row.Coef); j++ {
+				if psOpList[i].Row.Coef[j].Name == psOpList[i].Col.Name {
+					coef = psOpList[i].Row.Coef[j].Value
+				} else {
+					change += psOpList[i].Row.Coef[j].Value * origRay[psOpList[i].Row.Coef[j].Name]
+				}
+			}
+
+			if coef == 0 {
+				return errors.New("PostSolveRay unable to find coefficient")
+			}
+
+			origRay[psOpList[i].Col.Name] = -change / coef
+
+		// The merged ray is kept by the retained column if its bounds allow it,
+		// and otherwise moved to the deleted column.
+		case psopDupCol:
+			mrgVal = origRay[psOpList[i].Ref]
+			origRay[psOpList[i].Col.Name] = 0
+
+			if (mrgVal > 0 && psOpList[i].BndUp < Plinfy) ||
+				(mrgVal < 0 && psOpList[i].BndLo > -Plinfy) {
+				origRay[psOpList[i].Col.Name] = mrgVal / psOpList[i].Ratio
+				origRay[psOpList[i].Ref]      = 0
+			}
+
+		// All other operations remove rows or change bounds only.
+		default:
+			continue
+
+		} // End switch on operation type
+	} // End for processing psOpList
+
 	return nil
 }
 
 //==============================================================================
-// EXPORTED FUNCTIONS
+
+// spxRayMap returns the nonzero items of the ray returned by SimplexSolve in the
+// solution passed to the function (soln), keyed by column name.
+func spxRayMap(soln SpxSoln) map[string]float64 {
+
+	ray := make(map[string]float64)
+	for j := 0; j < len(Cols); j++ {
+		if soln.Ray[j] != 0 {
+			ray[Cols[j].Name] = soln.Ray[j]
+		}
+	}
+
+	return ray
+}
+
 //==============================================================================
 
-// ReduceMatrix iteratively performs the reduction operations specified in the psControl 
-// structure to remove rows and columns from the model until no more reductions
-// occur, or until the maximum number of iterations is reached. The function also 
-// performs some additional reductions (e.g. removal of empty rows) which are not configurable.
-//
-// In case of failure, the function returns an error.
-//
-//	The fields of the psControl structure have the following meaning for this function:
-//	   MaxIter           int    - maximum iterations for reduction loop
-//	   DelRowNonbinding  bool   - if true, remove non-binding rows
-//	   DelRowSingleton   bool   - if true, remove row singletons
-//	   DelColSingleton   bool   - if true, remove column singletons
-//	   DelFixedVars      bool   - if true, remove fixed variables
-//	   RunSolver         bool   - ignored by this function 		
-//	   FileInMps         string - ignored by this function
-//	   FileOutSoln       string - ignored by this function
-//	   FileOutMpsRdcd    string - ignored by this function
-//	   FileOutPsop       string - ignored by this function
-func ReduceMatrix(psControl PsCtrl) error {
-	var itemsFound  int  // number of items deleted by a specific operation
-	var itemsInPass int  // number of changes made in current iteration
-	var numChanges  int  // number of changes made in all iterations
-	var totalIter   int  // number of iterations performed by TightenBounds
-	var err       error  // error returned by secondary functions called
+// newUnbndError returns the error describing the unboundedness of the model
+// (reason), with the ray found by presolve completed to the original model. If
+// presolve found no ray, the ray of the reduced model returned by the solver
+// (solverRay), keyed by column name and in the units of the model passed to the
+// solver, is used. If the solver returned none either, solve is true, and the
+// reduced model is small enough, the ray is found by solving it with SimplexSolve.
+func newUnbndError(reason string, solverRay map[string]float64, solve bool) *PsUnbndError {
+	var soln SpxSoln  // solution of the reduced model
+
+	unbErr := &PsUnbndError{Reason: reason, Col: psUnbndCol}
+	ray    := psUnbndRay
+
+	if ray == nil && solverRay == nil && solve && spxFits() {
+		if err := SimplexSolve(&soln); err == nil && soln.Status == SpxUnbounded {
+			solverRay = spxRayMap(soln)
+		}
+	}
 
-	numChanges = 0
-	
-	for i := 1; i <= psControl.MaxIter; i++ {
+	if ray == nil && solverRay != nil {
+		ray = make(map[string]float64)
+		for name, value := range solverRay {
+			ray[name] = value / scaleOf(psScaleCol, name)
+		}
+	}
 
-		// Iterate over row and column reductions until no more changes in the
-		// number of elements are observed. The NumElements global counter is 
-		// updated whenever rows or columns are deleted.
-		
-		itemsInPass = 0
-		
-		log(pINFO, "\nIteration %d: %d rows, %d cols, %d elements.\n", i,
-			len(Rows), len(Cols), len(Elems))
+	if ray != nil {
+		unbErr.Ray = make(map[string]float64)
+		if err := PostSolveRay(ray, unbErr.Ray); err != nil {
+			log(pERR, "ERROR: %v\n", err)
+			unbErr.Ray = nil
+		}
+	}
 
-		if psControl.DelRowNonbinding {
+	return unbErr
+}
 
-			if err = TightenBounds(psControl.MaxIter, &totalIter); err != nil {
-				return errors.Wrap(err, "TightenBounds failed")		
-			}
-			
-			if err = delNbRows(&itemsFound); err != nil {
-				numChanges += itemsFound
-				return errors.Wrap(err, "ReduceMatrix failed")				
-			}
-					
-			itemsInPass += itemsFound
-		} // End if non-binding row
+//==============================================================================
 
+// scaleForSolver scales the reduced model with the method specified (method), or
+// does nothing if method is empty, and records the factors applied to each row and
+// column so that the solution of the scaled model can be unscaled by unscaleSoln.
+// The scaling report is returned in scReport.
+// In case of failure, function returns an error.
+func scaleForSolver(method string, scReport *ScaleReport) error {
+	var rowScale []float64  // row scale factors before scaling
+	var colScale []float64  // column scale factors before scaling
 
-		if psControl.DelFixedVars || psControl.DelRowNonbinding {
-			// This component must be executed if non-binding rows were removed.
-			if err = delFixedVars(&itemsFound); err != nil {
-				numChanges += itemsFound
-				return errors.Wrap(err, "ReduceMatrix failed")
-			}
+	psScaleRow = nil
+	psScaleCol = nil
 
-			itemsInPass += itemsFound
-		} // End if fixed variable
+	if method == "" {
+		return nil
+	}
 
+	rowScale = make([]float64, len(Rows))
+	colScale = make([]float64, len(Cols))
 
-		if psControl.DelRowSingleton {
-			if err = delRowSingletons(&itemsFound); err != nil {
-				numChanges += itemsFound
-				return errors.Wrap(err, "ReduceMatrix failed")							
-			}
-			
-			itemsInPass += itemsFound			
-		} // End if row singleton	
+	for i := 0; i < len(Rows); i++ {
+		rowScale[i] = Rows[i].ScaleFactor
+	}
 
-						
-		if psControl.DelColSingleton {
-			if err = delFreeColSingls(&itemsFound); err != nil {
-				numChanges += itemsFound
-				return errors.Wrap(err, "ReduceMatrix failed")								
-			}
-			
-			itemsInPass += itemsFound						
-		} // End if column singleton
+	for j := 0; j < len(Cols); j++ {
+		colScale[j] = Cols[j].ScaleFactor
+	}
 
-		// Empty rows are deleted automatically without any configurable flag.	
-		if err = delEmptyRows(&itemsFound); err != nil {
-			numChanges += itemsFound
-			return errors.Wrap(err, "ReduceMatrix failed")											
-		}
+	if err := ScaleModel(method, scReport); err != nil {
+		return errors.Wrap(err, "scaleForSolver failed")
+	}
 
-		// Empty cols are deleted automatically without any configurable flag.	
-		if err = delEmptyCols(&itemsFound); err != nil {
-			numChanges += itemsFound
-			return errors.Wrap(err, "ReduceMatrix failed")											
+	psScaleRow = make(map[string]float64)
+	psScaleCol = make(map[string]float64)
+
+	for i := 0; i < len(Rows); i++ {
+		if Rows[i].ScaleFactor != rowScale[i] {
+			psScaleRow[Rows[i].Name] = Rows[i].ScaleFactor / rowScale[i]
 		}
+	}
 
-		// Increment counters and print status when done.
-		itemsInPass += itemsFound		
-		numChanges  += itemsInPass
-				
-		if itemsInPass == 0 {
-			log(pINFO, "Reduction done after %d of %d iterations, %d items removed.\n", 
-					i, psControl.MaxIter, numChanges)
-			break
+	for j := 0; j < len(Cols); j++ {
+		if Cols[j].ScaleFactor != colScale[j] {
+			psScaleCol[Cols[j].Name] = Cols[j].ScaleFactor / colScale[j]
 		}
+	}
 
-	} // End for maximum iterations loop
-	
 	return nil
 }
 
 //==============================================================================
 
-// WritePsopFile writes the rows and columns that were removed during the pre-solve
-// operations to a text file specified by the user. The function accepts two
-// arguments, fileName and coefPerLine. If the file name the file to which the
-// output is written, and if empty, a default name is used. 
-//
-// The coefPerLine specifies how many coefficient name/value pairs should be 
-// written per line and is interpretted as follows:
-//	  < 0 - all pairs are written on a single line (no CR/LF is inserted between pairs)
-//	    0 - printing of coefficient name/value pairs is suppressed
-//	    n - a carriage return line feed is inserted after printing n pairs  
-// In case of failure, the function returns an error.
-func WritePsopFile(fileName string, coefPerLine int) error {
+// scaleOf returns the scale factor of the row or column (name) in the map of
+// factors passed to the function (scaleMap), or 1 if it was not scaled.
+func scaleOf(scaleMap map[string]float64, name string) float64 {
 
-	var opName       string // operation name in more detail than internal var. 
-	var rowPresent   bool   // flag indicating that row needs to be printed
-	var colPresent   bool   // flag indicating that column needs to be printed
-	var index        int    // index tracking how many coefficients were printed
-	var printCoef    bool   // controls if coef name/value pairs are printed
-	var coefCrNeeded bool   // controls if <CR> printed between coef name/value pairs
+	if factor, ok := scaleMap[name]; ok {
+		return factor
+	}
 
+	return 1
+}
 
-	//Check whether the file exists. If it exists, overwrite it.
+//==============================================================================
 
-	if _, err := os.Stat(fileName); err == nil {
-		err = os.Remove(fileName)
-		if err != nil {
-			return errors.Wrapf(err, "Failed to delete existing file %s", fileName)
+// unscaleSoln converts the solution of the scaled reduced model, passed to the
+// function as the constraint and variable maps (conMap, varMap), to the solution of
+// the reduced model before scaling, using the factors recorded by scaleForSolver.
+// Values are divided by the column factors and reduced costs multiplied by them,
+// while slacks are multiplied by the row factors and duals divided by them.
+func unscaleSoln(conMap PsResConMap, varMap PsResVarMap) {
+
+	for name, factor := range psScaleCol {
+		if mapItem, ok := varMap[name]; ok {
+			mapItem.Value       = mapItem.Value / factor
+			mapItem.ReducedCost = mapItem.ReducedCost * factor
+			varMap[name] = mapItem
 		}
 	}
-	
-	f, err := os.Create(fileName)
-	if err != nil {
-		return errors.Wrapf(err, "Failed to create new file %s", fileName)
-	}
-
- 	defer f.Close()
 
-	// Set flags to control printing of coefficient name/value pairs per line
-	printCoef    = true
-	coefCrNeeded = true
-	
-	if coefPerLine < 0	{
-		coefCrNeeded = false
-		coefPerLine *= -1
-	} else if coefPerLine == 0 {
-		printCoef = false
+	for name, factor := range psScaleRow {
+		if mapItem, ok := conMap[name]; ok {
+			mapItem.Slack = mapItem.Slack * factor
+			mapItem.Pi    = mapItem.Pi / factor
+			mapItem.Dual  = mapItem.Dual / factor
+			conMap[name] = mapItem
+		}
 	}
+}
 
-	// Print status message to screen and general header into the file.
-	log(pINFO, "\nWriting pre-solve operations to file %s.\n", fileName)
-
-	startTime := time.Now()
+//==============================================================================
 
-	fmt.Fprintf(f, "%s", fileDelim)
-	fmt.Fprintf(f, "# LPO record of pre-solve operations\n")	
-	fmt.Fprintf(f, "# Problem name: %s\n", Name)
-	fmt.Fprintf(f, "# Created on:   %s\n", startTime.Format("2006-01-02 15:04:05"))
-	fmt.Fprintf(f, "#\n# Col format:   COL:  Name  Type  LowerBound  UpperBound  ScaleFactor\n")
-	fmt.Fprintf(f, "# Row format:   ROW:  Name  Type  Rhs  ScaleFactor\n")
-	
-	if printCoef {
-		fmt.Fprintf(f, "# Followed by:  CoefName CoefValue (up to %d pairs/line)\n#\n", coefPerLine)
-	} else {
-		fmt.Fprintf(f, "# Coefficient name/value pairs for rows are not printed.\n#\n")
+// psStatusError returns the error to be reported when the solver (solver) finds
+// the reduced model unbounded or infeasible, with the status of the solution
+// (status) given as SpxUnbounded or SpxInfeasible. An unbounded model is reported
+// by a *PsUnbndError, with the ray returned by the solver (ray), keyed by column
+// name, completed to the original model, and an infeasible model by a
+// *PsInfeasError. The ray may be nil, in which case newUnbndError finds one if solve
+// is true. For any other status, nil is returned.
+func psStatusError(solver string, status string, ray map[string]float64, solve bool) error {
+
+	switch status {
+	case SpxUnbounded:
+		return newUnbndError(fmt.Sprintf("%s found model unbounded", solver), ray, solve)
+	case SpxInfeasible:
+		return &PsInfeasError{Reason: fmt.Sprintf("%s found model infeasible", solver)}
 	}
 
-	// Print the rows and cols associated with each PSOP in the list.
-	for i := 0; i < len(psOpList); i++ {
+	return nil
+}
 
-		// Set print flags based on operation type being processed.
-		switch psOpList[i].OpType {
-			
-			case psopEmptyRow:
-				opName     = "Empty Row"
-				rowPresent = true
-				colPresent = false
-							
-			case psopEmptyCol:
-				opName     = "Empty Column"
-				rowPresent = false
-				colPresent = true
+//==============================================================================
 
-			case psopFixedVar:
-				opName     = "Fixed Variable"
-				rowPresent = false
-				colPresent = true
-			
-			case psopFreeCol:
-				opName     = "Free Column Singleton"
-				rowPresent = true
-				colPresent = true
-			
-			case psopNbRow:
-				opName     = "Non-binding Row"
-				rowPresent = true
-				colPresent = false
-			
-			case psopRowSingltn:
-				opName     = "Row Singleton"
-				rowPresent = true
-				colPresent = true
-			
-			default:
-				opName     = "Unknown Operation"
-				rowPresent = false
-				colPresent = false
-						
-		} // End switch on operation type
+// psSolverError returns the error to be reported when the solver (solver) fails to
+// return a solution of the reduced model with the error passed in (err), and the
+// status of the solution could not be obtained from the solver. If presolve found
+// the model unbounded, or solve is true and the reduced model is small enough to
+// be solved by SimplexSolve and is found to be unbounded or infeasible, the error
+// returned is a *PsUnbndError or *PsInfeasError. Otherwise err is returned.
+func psSolverError(solver string, err error, solve bool) error {
+	var soln SpxSoln  // solution of the reduced model
+
+	if psUnbounded {
+		return newUnbndError(fmt.Sprintf("%s found no solution, presolve found model unbounded", solver), nil, solve)
+	}
 
-		// Print the operation type, ID
-		fmt.Fprintf(f, "%s", fileDelim)
-		fmt.Fprintf(f, "# %s\n", opName)		
-		fmt.Fprintf(f, "PSOP: %s %5d\n", psOpList[i].OpType, i)
+	if !solve || !spxFits() || SimplexSolve(&soln) != nil {
+		return err
+	}
 
-		if colPresent {
-			fmt.Fprintf(f, "COL:  %s   %s %15e %15e %15e\n", 
-				psOpList[i].Col.Name, psOpList[i].Col.Type,
-				psOpList[i].Col.BndLo, psOpList[i].Col.BndUp, psOpList[i].Col.ScaleFactor)				
-		} // End if column was printed		
-		
-		if rowPresent {
-			fmt.Fprintf(f, "ROW:  %s   %s %15e %15e\n", 
-				psOpList[i].Row.Name, psOpList[i].Row.Type, 
-				psOpList[i].Row.Rhs, psOpList[i].Row.ScaleFactor)
-
-			if printCoef {
-				for index = 0; index < len(psOpList[i].Row.Coef); index++ {
-					fmt.Fprintf(f, "%15s %15e", psOpList[i].Row.Coef[index].Name, 
-							psOpList[i].Row.Coef[index].Value)
-
-					// Print two pairs of coef. per line, and extra CR if odd number
-					if coefCrNeeded && ((index + 1) % coefPerLine) == 0 {
-						fmt.Fprintf(f, "\n")
-					}				
-				} // End for list of coefficients
-
-				if coefCrNeeded && index != 0 {
-					if ((index) % coefPerLine) != 0 {
-						fmt.Fprintf(f, "\n")				
-					} // End if extra <CR> needed after last coef.				
-				} // End if some coefficients were present			
-				
-			} // End for printing coefficients
-		} // End if row was printed				
-	} // End for processing post-solve operations list
+	switch soln.Status {
+	case SpxUnbounded:
+		return newUnbndError(fmt.Sprintf("%s found no solution, model is unbounded", solver), spxRayMap(soln), solve)
+	case SpxInfeasible:
+		return &PsInfeasError{Reason: fmt.Sprintf("%s found no solution, model is infeasible", solver)}
+	}
 
-	log(pINFO, "Successfully wrote %d operations.\n", len(psOpList))
-			
-	return nil
+	return err
 }
 
 //==============================================================================
@@ -1648,6 +4928,119 @@ func CplexSolveMps(mpsFile string, solnFile string, psFile string, soln *CplexSo
 	return nil
 }
 
+//==============================================================================
+
+// parseCplexRangeValue converts a value printed by Cplex in a sensitivity table
+// (field) to a number, with "zero" converted to 0 and infinite values to -Plinfy
+// or Plinfy. The function returns false if the field is not a value.
+func parseCplexRangeValue(field string, value *float64) bool {
+	var err error  // error returned by strconv
+
+	switch strings.ToLower(field) {
+	case "zero":
+		*value = 0
+	case "-infinity":
+		*value = -Plinfy
+	case "infinity", "+infinity":
+		*value = Plinfy
+	default:
+		if *value, err = strconv.ParseFloat(field, 64); err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+//==============================================================================
+
+// parseCplexRange parses the sensitivity tables printed by Cplex (out) and adds
+// the RHS ranges to rhsRange and the objective ranges to objRange. Each line of a
+// table lists the name, the dual value or reduced cost, and the lowest, current,
+// and highest values of the RHS or objective coefficient.
+func parseCplexRange(out string, rhsRange PsRangeMap, objRange PsRangeMap) {
+	var rngMap PsRangeMap  // map to which lines of current table are added
+	var item      PsRange  // range parsed from the line
+	var dual      float64  // dual value or reduced cost, not used
+
+	for _, line := range strings.Split(out, "\n") {
+		switch {
+		case strings.Contains(line, "RHS Sensitivity Ranges"):
+			rngMap = rhsRange
+			continue
+		case strings.Contains(line, "OBJ Sensitivity Ranges"):
+			rngMap = objRange
+			continue
+		case rngMap == nil:
+			continue
+		}
+
+		field := strings.Fields(line)
+		if len(field) != 5 || !parseCplexRangeValue(field[1], &dual) ||
+			!parseCplexRangeValue(field[2], &item.Lo) ||
+			!parseCplexRangeValue(field[3], &item.Value) ||
+			!parseCplexRangeValue(field[4], &item.Up) {
+			continue
+		}
+
+		item.Status = RangeOk
+		rngMap[field[0]] = item
+	} // End for all lines of output
+}
+
+//==============================================================================
+
+// CplexRangeMps uses Cplex to solve the LP defined in the MPS file specified
+// (mpsFile), and adds the range of the RHS of every row within which the optimal
+// basis does not change to rhsRange, and the range of the objective coefficient
+// of every column to objRange, both keyed by name. The ranges are obtained from
+// the sensitivity tables printed by Cplex. Cplex does not say which bound of a
+// row its range applies to, so the Bound field of the RHS ranges is left empty.
+// In case of failure, function returns an error.
+func CplexRangeMps(mpsFile string, rhsRange PsRangeMap, objRange PsRangeMap) error {
+	var cplexCmdFile string  // command file telling Cplex what to do
+	var bigString    string  // stdout text generated by Cplex
+	var strStart        int  // return value from strings.Index used in parsing stdout
+	var err           error  // error returned by secondary functions called
+
+	if rhsRange == nil || objRange == nil {
+		return errors.New("CplexRangeMps received nil map")
+	}
+
+	cplexCmdFile = tempDirPath + "/cpxRangeCommands.txt"
+
+	f, err := os.Create(cplexCmdFile)
+	if err != nil {
+		return errors.Wrap(err, "CplexRangeMps failed to create command file")
+	}
+
+	fmt.Fprintln(f, "read", mpsFile, "mps")           //command to read the MPS file
+	fmt.Fprintln(f, "optimize")                       //optimize command
+	fmt.Fprintln(f, "display sensitivity rhs -")      //print the RHS ranges
+	fmt.Fprintln(f, "display sensitivity obj -")      //print the objective ranges
+	f.Close()
+
+	out, err := exec.Command("cplex", "-f", cplexCmdFile).Output()
+	if err != nil {
+		return errors.Wrap(err, "Exec command for Cplex failed in CplexRangeMps")
+	}
+
+	// Report the first error printed by Cplex, e.g. if the model is not an LP.
+	bigString = string(out)
+	strStart  = strings.Index(bigString, "CPLEX Error")
+	if strStart >= 0 {
+		strEnd := strings.Index(bigString[strStart:], "\n")
+		if strEnd < 0 {
+			strEnd = len(bigString) - strStart
+		}
+		return errors.New(strings.TrimSpace(bigString[strStart:strStart + strEnd]))
+	}
+
+	parseCplexRange(bigString, rhsRange, objRange)
+
+	return nil
+}
+
 //============================ END OF FILE =====================================
 
 
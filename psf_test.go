@@ -0,0 +1,324 @@
+//==============================================================================
+// psf_test: TESTS of presolve and postsolve
+// 01   Oct. 18, 2026   Initial version
+
+
+// The tests reduce and solve the sample LP models supplied with lporun (AFIRO and
+// BORE3D) with the in-process simplex solver, and complete the solution by
+// postsolve, both in the same process and from the list of pre-solve operations
+// written to a file and read back after the model is loaded again, as another
+// process would do. The duals of the complete solution are checked for dual
+// feasibility against the original model. The slack of the rows of the sample
+// MILP model (p0033) changed by presolve is checked against the original rows,
+// an infeasible model is checked to be reported as such by presolve, and the
+// status returned by a solver is checked to be reported by the typed errors.
+
+package lpo
+
+import (
+	"github.com/pkg/errors"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+//==============================================================================
+
+// TestPsopFileRoundTrip checks that the solution completed from the PSOP file is
+// the one completed from the operations recorded by ReduceMatrix.
+func TestPsopFileRoundTrip(t *testing.T) {
+	var psRslt  PsSoln   // solution completed in process
+	var fileRslt PsSoln  // solution completed from the PSOP file
+
+	quietLog(t)
+	fileName := filepath.Join(t.TempDir(), "psop.txt")
+	loadModel(t, "lporun/inputSmallLp.txt")
+
+	if err := ReduceMatrix(samplePsCtrl()); err != nil {
+		t.Fatalf("ReduceMatrix: %v", err)
+	}
+	if len(psOpList) == 0 {
+		t.Fatalf("ReduceMatrix recorded no operations")
+	}
+
+	conMap := make(PsResConMap)
+	varMap := make(PsResVarMap)
+	solveReducedLp(t, conMap, varMap)
+
+	if err := PostSolve(conMap, varMap, &psRslt); err != nil {
+		t.Fatalf("PostSolve: %v", err)
+	}
+
+	if err := WritePsopFile(fileName, -1); err != nil {
+		t.Fatalf("WritePsopFile: %v", err)
+	}
+
+	loadModel(t, "lporun/inputSmallLp.txt")
+	if err := ReadPsopFile(fileName); err != nil {
+		t.Fatalf("ReadPsopFile: %v", err)
+	}
+
+	if err := PostSolve(conMap, varMap, &fileRslt); err != nil {
+		t.Fatalf("PostSolve from file: %v", err)
+	}
+
+	if math.Abs(psRslt.ObjVal + 464.753143) > 1.0e-5 {
+		t.Errorf("objective %f, want -464.753143", psRslt.ObjVal)
+	}
+
+	if !reflect.DeepEqual(fileRslt, psRslt) {
+		t.Errorf("solution from file (objective %f) differs from solution in process (objective %f)",
+			fileRslt.ObjVal, psRslt.ObjVal)
+	}
+}
+
+//==============================================================================
+
+// checkPostSolveDuals reduces and solves the model in the file passed in
+// (fileName), completes its solution by postsolve, and checks that the reduced
+// costs and row duals returned are dual feasible for the original model: the
+// reduced cost of each column is its objective coefficient less the duals of its
+// rows, a positive (negative) reduced cost has the column at its lower (upper)
+// bound, and a positive (negative) row dual has the row at its lower (upper) side.
+// Presolve stops tightening a bound when it moves by less than Featol, so the
+// reduced costs are checked relative to the terms they are calculated from.
+func checkPostSolveDuals(t *testing.T, fileName string) {
+	var psRslt PsSoln   // solution of the original model
+	var eval PointEval  // evaluation of the solution against the original model
+	var tol   float64   // tolerance of the check being made
+	var value float64   // value of column being checked
+	var redCost float64 // reduced cost of column being checked
+	var dual  float64   // dual of row being checked
+	var act   float64   // activity of row being checked
+	var colSize []float64 // sum of the magnitudes of the terms of each reduced cost
+
+	quietLog(t)
+	loadModel(t, fileName)
+
+	if err := ReduceMatrix(samplePsCtrl()); err != nil {
+		t.Fatalf("%s: ReduceMatrix: %v", fileName, err)
+	}
+
+	conMap := make(PsResConMap)
+	varMap := make(PsResVarMap)
+	solveReducedLp(t, conMap, varMap)
+
+	if err := PostSolve(conMap, varMap, &psRslt); err != nil {
+		t.Fatalf("%s: PostSolve: %v", fileName, err)
+	}
+
+	// The checks are made against the model as it was before presolve.
+	loadModel(t, fileName)
+	evalSoln(t, psRslt, &eval)
+
+	colCost := make([]float64, len(Cols))
+	colSize  = make([]float64, len(Cols))
+	for k := 0; k < len(Rows[ObjRow].HasElems); k++ {
+		colCost[Elems[Rows[ObjRow].HasElems[k]].InCol] = Elems[Rows[ObjRow].HasElems[k]].Value
+		colSize[Elems[Rows[ObjRow].HasElems[k]].InCol] = math.Abs(Elems[Rows[ObjRow].HasElems[k]].Value)
+	}
+
+	for i := 0; i < len(Rows); i++ {
+		if i == ObjRow {
+			continue
+		}
+
+		act = eval.RowLhs[i]
+
+		dual = psRslt.ConMap[Rows[i].Name].Dual
+		for k := 0; k < len(Rows[i].HasElems); k++ {
+			colCost[Elems[Rows[i].HasElems[k]].InCol] -= dual * Elems[Rows[i].HasElems[k]].Value
+			colSize[Elems[Rows[i].HasElems[k]].InCol] += math.Abs(dual * Elems[Rows[i].HasElems[k]].Value)
+		}
+
+		tol = 1.0e-6 * math.Max(1, math.Abs(act))
+		if (dual > 1.0e-6 && math.Abs(act - Rows[i].RHSlo) > tol) ||
+			(dual < -1.0e-6 && math.Abs(act - Rows[i].RHSup) > tol) {
+			t.Errorf("%s: row %s dual %e with activity %f in [%f, %f]", fileName, Rows[i].Name,
+				dual, act, Rows[i].RHSlo, Rows[i].RHSup)
+		}
+	} // End for all rows
+
+	for j := 0; j < len(Cols); j++ {
+		value   = psRslt.VarMap[Cols[j].Name].Value
+		redCost = psRslt.VarMap[Cols[j].Name].ReducedCost
+
+		tol = 1.0e-6 * math.Max(1, colSize[j])
+		if math.Abs(redCost - colCost[j]) > tol {
+			t.Errorf("%s: column %s reduced cost %e, want %e", fileName, Cols[j].Name,
+				redCost, colCost[j])
+		}
+
+		if (redCost > tol && math.Abs(value - Cols[j].BndLo) > 1.0e-6 * math.Max(1, math.Abs(value))) ||
+			(redCost < -tol && math.Abs(value - Cols[j].BndUp) > 1.0e-6 * math.Max(1, math.Abs(value))) {
+			t.Errorf("%s: column %s reduced cost %e with value %f in [%f, %f]", fileName,
+				Cols[j].Name, redCost, value, Cols[j].BndLo, Cols[j].BndUp)
+		}
+	} // End for all columns
+}
+
+//==============================================================================
+
+// TestPostSolveDualsAfiro checks the duals of the small sample LP model (AFIRO).
+func TestPostSolveDualsAfiro(t *testing.T) {
+
+	checkPostSolveDuals(t, "lporun/inputSmallLp.txt")
+}
+
+//==============================================================================
+
+// TestPostSolveDualsBore3d checks the duals of the large sample LP model (BORE3D).
+func TestPostSolveDualsBore3d(t *testing.T) {
+
+	if testing.Short() {
+		t.Skip("skipping BORE3D in short mode")
+	}
+
+	checkPostSolveDuals(t, "lporun/inputLargeLP.txt")
+}
+
+//==============================================================================
+
+// TestPostSolveSlackMilp checks that the slack completed by postsolve for the rows
+// of the sample MILP model (p0033) whose coefficients were tightened or which were
+// extended by presolve is that of the original row, rather than the slack of the
+// changed row returned by the solver.
+func TestPostSolveSlackMilp(t *testing.T) {
+	var psRslt PsSoln   // solution of the original model
+	var eval PointEval  // evaluation of the solution against the original model
+	var act   float64   // activity of row being checked
+
+	quietLog(t)
+	loadModel(t, "lporun/inputSmallMilp.txt")
+
+	psCtrl := samplePsCtrl()
+	psCtrl.TightenCoefs = true
+	psCtrl.MergeCliques = true
+	if err := ReduceMatrix(psCtrl); err != nil {
+		t.Fatalf("ReduceMatrix: %v", err)
+	}
+
+	chgdRows := make(map[string]bool)
+	for i := 0; i < len(psOpList); i++ {
+		if psOpList[i].OpType == psopCoefTight || psOpList[i].OpType == psopCliqueExt {
+			chgdRows[psOpList[i].Row.Name] = true
+		}
+	}
+	if len(chgdRows) == 0 {
+		t.Fatalf("ReduceMatrix changed no rows")
+	}
+
+	conMap := make(PsResConMap)
+	varMap := make(PsResVarMap)
+	solveReducedLp(t, conMap, varMap)
+
+	if err := PostSolve(conMap, varMap, &psRslt); err != nil {
+		t.Fatalf("PostSolve: %v", err)
+	}
+
+	// The checks are made against the model as it was before presolve.
+	loadModel(t, "lporun/inputSmallMilp.txt")
+	evalSoln(t, psRslt, &eval)
+
+	for i := 0; i < len(Rows); i++ {
+		if !chgdRows[Rows[i].Name] {
+			continue
+		}
+
+		act = eval.RowLhs[i]
+
+		conItem := psRslt.ConMap[Rows[i].Name]
+		if math.Abs(conItem.Slack - (conItem.Rhs - act)) > 1.0e-6 * math.Max(1, math.Abs(act)) {
+			t.Errorf("row %s slack %f, want %f", Rows[i].Name, conItem.Slack, conItem.Rhs - act)
+		}
+	} // End for all rows changed
+}
+
+//==============================================================================
+
+// TestDupRowsInfeasible checks that the infeasibility found when two duplicate
+// rows have conflicting bounds is returned as a *PsInfeasError naming the row
+// retained, and that the report gives it as the reason presolve stopped.
+func TestDupRowsInfeasible(t *testing.T) {
+	var psReport PsReport  // report of the presolve operations
+
+	quietLog(t)
+	fileName := filepath.Join(t.TempDir(), "duprows.mps")
+	mps := "NAME          DUPROWS\n" +
+		"ROWS\n N  OBJ\n L  R1\n G  R2\n" +
+		"COLUMNS\n" +
+		"    X         OBJ       1.0        R1        1.0\n" +
+		"    X         R2        2.0\n" +
+		"    Y         OBJ       1.0        R1        1.0\n" +
+		"    Y         R2        2.0\n" +
+		"RHS\n    RHS       R1        1.0        R2        4.0\n" +
+		"BOUNDS\n UP BND       X         10.0\n UP BND       Y         10.0\n" +
+		"ENDATA\n"
+	if err := os.WriteFile(fileName, []byte(mps), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	loadModel(t, fileName)
+
+	err := ReduceMatrixReport(PsCtrl{MaxIter: 10, DelDupRows: true}, &psReport)
+	if err == nil {
+		t.Fatalf("ReduceMatrixReport found model feasible")
+	}
+
+	infErr, ok := errors.Cause(err).(*PsInfeasError)
+	if !ok {
+		t.Fatalf("ReduceMatrixReport error %v is not a *PsInfeasError", err)
+	}
+	if infErr.Row != "R1" && infErr.Row != "R2" {
+		t.Errorf("infeasibility found at row %q, want R1 or R2", infErr.Row)
+	}
+
+	if psReport.StopReason != PsStopInfeasible {
+		t.Errorf("stop reason %s, want %s", psReport.StopReason, PsStopInfeasible)
+	}
+}
+
+//==============================================================================
+
+// TestStatusErrorRay checks that an unbounded or infeasible status returned by a
+// solver is reported as a *PsUnbndError holding the ray returned by the solver,
+// or as a *PsInfeasError, and that no error is returned for any other status.
+func TestStatusErrorRay(t *testing.T) {
+
+	quietLog(t)
+	fileName := filepath.Join(t.TempDir(), "unbnd.mps")
+	mps := "NAME          UNBND\n" +
+		"ROWS\n N  OBJ\n G  R1\n" +
+		"COLUMNS\n" +
+		"    X         OBJ       -1.0       R1        1.0\n" +
+		"    Y         R1        -1.0\n" +
+		"RHS\n    RHS       R1        0.0\n" +
+		"ENDATA\n"
+	if err := os.WriteFile(fileName, []byte(mps), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	loadModel(t, fileName)
+
+	err := psStatusError("Test", SpxUnbounded, map[string]float64{"X": 1, "Y": 1}, false)
+	unbErr, ok := err.(*PsUnbndError)
+	if !ok {
+		t.Fatalf("unbounded status returned %v, not a *PsUnbndError", err)
+	}
+	if want := map[string]float64{"X": 1, "Y": 1}; !reflect.DeepEqual(unbErr.Ray, want) {
+		t.Errorf("ray %v, want %v", unbErr.Ray, want)
+	}
+
+	if err = psStatusError("Test", SpxInfeasible, nil, false); err == nil {
+		t.Errorf("infeasible status returned no error")
+	} else if _, ok = err.(*PsInfeasError); !ok {
+		t.Errorf("infeasible status returned %v, not a *PsInfeasError", err)
+	}
+
+	if err = psStatusError("Test", SpxOptimal, nil, false); err != nil {
+		t.Errorf("optimal status returned %v", err)
+	}
+}
+
+//============================ END OF FILE =====================================
@@ -0,0 +1,1023 @@
+//==============================================================================
+// psmip: PreSolve functions for MIP models
+// 01   Oct. 18, 2026   Initial version
+
+
+// This file contains presolve functions which only apply to MILP models. They
+// use the integrality of the variables to derive fixings, bounds and relations
+// between variables that are not valid for the LP relaxation alone.
+
+package lpo
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"math"
+	"sort"
+	"time"
+)
+
+
+// PsImpl describes an implication found while probing a binary variable: if the
+// binary variable takes the value BinVal, then the implied variable must lie
+// within the bounds given. If the implied variable is itself binary and the
+// bounds are equal, the implication is between two binary variables.
+type PsImpl struct {
+	BinCol  string   // Name of binary column that was probed
+	BinVal  float64  // Value (0 or 1) of the binary column
+	Col     string   // Name of column whose bounds are implied
+	BndLo   float64  // Implied lower bound of the column
+	BndUp   float64  // Implied upper bound of the column
+}
+
+// PsClique describes a set of binary variables of which at most one can take the
+// value 1, or exactly one if Equal is set. Cliques derived from a row of the model
+// carry the name of that row, while those derived from conflicts between pairs of
+// binary variables found during probing have an empty row name.
+type PsClique struct {
+	Cols   []string  // Names of binary columns in the clique
+	Equal  bool      // True if exactly one of the columns must take the value 1
+	Row    string    // Name of row from which clique was derived, or "" if none
+}
+
+// psClique is used internally when processing cliques, and stores the row index,
+// the sorted indices of the columns in the clique, and the type of clique.
+type psClique struct {
+	Row    int       // Index of row from which clique was derived, or -1 if none
+	Cols   []int     // Sorted indices of binary columns in the clique
+	Equal  bool      // True if exactly one of the columns must take the value 1
+}
+
+// psConflicts is the conflict graph of the binary columns of a MILP model, in
+// which two columns are adjacent if they cannot both take the value 1. Columns of
+// the same clique are adjacent implicitly, through the list of cliques of each
+// column, so that the graph grows with the size of the clique rows rather than its
+// square. Only the conflicts found by probing are stored as pairs of columns.
+type psConflicts struct {
+	Cliques    []psClique            // Cliques derived from multiple choice rows
+	ColCliques map[int][]int         // Indices in Cliques of the cliques of each column
+	Pairs      map[int]map[int]bool  // Pairs of columns found in conflict by probing
+}
+
+// psProbeWork holds the lists used by propagateBounds for one value of a probed
+// column. Only the rows and columns reached by the probe are listed, so that the
+// cost of a probe depends on the part of the model it affects rather than on the
+// size of the model, and the lists are allocated once for all columns probed.
+type psProbeWork struct {
+	RowMark   []bool  // True if row is in the list of rows checked next round
+	CheckRows []int   // Rows to be checked in the current round
+	NextRows  []int   // Rows to be checked in the next round
+	ColMark   []bool  // True if column is in the list of changed columns
+	Changed   []int   // Columns whose bounds were changed by the probe
+}
+
+// Number of rounds of bound propagation performed for each probe
+const psProbeRounds = 20
+
+// Package global variables
+var psImplList   []PsImpl    // Implications found during the most recent probing
+var psCliqueList []PsClique  // Clique table built during the most recent clique pass
+
+
+//==============================================================================
+// BOUND PROPAGATION AND PROBING
+//==============================================================================
+
+// isBinary returns true if the column specified by colIndex is an integer
+// variable with bounds of 0 and 1.
+func isBinary(colIndex int) bool {
+
+	return Cols[colIndex].Type == "I" && Cols[colIndex].BndLo == 0 && Cols[colIndex].BndUp == 1
+}
+
+//==============================================================================
+
+// newProbeWork returns the lists used by propagateBounds for the current model,
+// with no rows or columns listed.
+func newProbeWork() *psProbeWork {
+
+	return &psProbeWork{RowMark: make([]bool, len(Rows)), ColMark: make([]bool, len(Cols))}
+}
+
+//==============================================================================
+
+// propagateBounds tightens the column bounds passed in (colLo, colUp) using the
+// same row by row logic as TightenBounds, but without changing the model. Only
+// rows containing the column specified by colIndex are checked initially, and
+// rows containing any column whose bounds changed are checked in the following
+// round, for at most maxRounds rounds. Infinite bounds are counted separately
+// when calculating the row activities, so they never produce a finite bound.
+// The columns whose bounds changed are added to the list of changed columns in
+// work, starting with colIndex, whose bounds are set by the caller. The caller
+// restores their bounds with resetProbe before the next probe.
+// The function sets the infeasible flag if a row cannot be satisfied or the
+// bounds of a column are reversed.
+// In case of failure, function returns an error.
+func propagateBounds(colLo []float64, colUp []float64, colIndex int, maxRounds int,
+	work *psProbeWork, infeasible *bool) error {
+	var minAct    float64  // minimum activity of row being processed
+	var maxAct    float64  // maximum activity of row being processed
+	var minInf        int  // number of infinite contributions to minimum activity
+	var maxInf        int  // number of infinite contributions to maximum activity
+	var resid     float64  // activity of the row without the column being processed
+	var numInf        int  // number of infinite contributions to resid
+	var newBnd    float64  // bound implied by the row for the column being processed
+	var coef      float64  // coefficient of element being processed
+	var bndLo     float64  // bound giving minimum contribution of column
+	var bndUp     float64  // bound giving maximum contribution of column
+	var icol          int  // index of column being processed
+	var index         int  // index of element being processed
+
+	if len(colLo) != len(Cols) || len(colUp) != len(Cols) {
+		return errors.New("propagateBounds received bound lists of wrong size")
+	}
+
+	if len(work.RowMark) != len(Rows) || len(work.ColMark) != len(Cols) {
+		return errors.New("propagateBounds received work lists of wrong size")
+	}
+
+	if colIndex < 0 || colIndex >= len(Cols) {
+		return errors.Errorf("Column index %d out of range in propagateBounds", colIndex)
+	}
+
+	*infeasible = false
+	work.CheckRows = work.CheckRows[:0]
+	work.NextRows  = work.NextRows[:0]
+
+	// Rows still listed for the next round when the function returns must not
+	// remain marked for the next probe.
+	defer func() {
+		for _, i := range work.NextRows {
+			work.RowMark[i] = false
+		}
+		work.NextRows = work.NextRows[:0]
+	}()
+
+	markColChanged(colIndex, work)
+
+	for round := 0; len(work.NextRows) > 0 && round < maxRounds; round++ {
+		work.CheckRows, work.NextRows = work.NextRows, work.CheckRows[:0]
+		for _, i := range work.CheckRows {
+			work.RowMark[i] = false
+		}
+
+		// Rows are checked in the order of the model, as if all rows were
+		// scanned, so that the bounds derived do not depend on the order in
+		// which the rows were reached.
+		sort.Ints(work.CheckRows)
+
+		for _, i := range work.CheckRows {
+			if Rows[i].Type == "N" || Rows[i].State == stateDelete {
+				continue
+			}
+
+			// Calculate the activities from the bounds passed in rather than
+			// those stored in Cols.
+			minAct, maxAct, minInf, maxInf = 0, 0, 0, 0
+
+			for j := 0; j < len(Rows[i].HasElems); j++ {
+				index = Rows[i].HasElems[j]
+				coef  = Elems[index].Value
+				bndLo = colLo[Elems[index].InCol]
+				bndUp = colUp[Elems[index].InCol]
+				if coef < 0 {
+					bndLo, bndUp = bndUp, bndLo
+				}
+
+				if math.Abs(bndLo) >= Plinfy {
+					minInf++
+				} else {
+					minAct += coef * bndLo
+				}
+
+				if math.Abs(bndUp) >= Plinfy {
+					maxInf++
+				} else {
+					maxAct += coef * bndUp
+				}
+			} // End for all elements in row
+
+			if (minInf == 0 && Rows[i].RHSup < Plinfy && minAct > Rows[i].RHSup + Featol) ||
+				(maxInf == 0 && Rows[i].RHSlo > -Plinfy && maxAct < Rows[i].RHSlo - Featol) {
+				*infeasible = true
+				return nil
+			}
+
+			for j := 0; j < len(Rows[i].HasElems); j++ {
+				index = Rows[i].HasElems[j]
+				icol  = Elems[index].InCol
+				coef  = Elems[index].Value
+				bndLo = colLo[icol]
+				bndUp = colUp[icol]
+				if coef < 0 {
+					bndLo, bndUp = bndUp, bndLo
+				}
+
+				// The upper bound of the row limits the column if the minimum
+				// activity of the remaining columns is finite.
+				if Rows[i].RHSup < Plinfy {
+					resid, numInf = removeActivity(minAct, minInf, coef * bndLo, bndLo)
+
+					if numInf == 0 {
+						newBnd = (Rows[i].RHSup - resid) / coef
+						if coef > 0 && newBnd < colUp[icol] - Featol {
+							if Cols[icol].Type == "I" {
+								newBnd = snap(newBnd, "U")
+							}
+							colUp[icol] = newBnd
+							markColChanged(icol, work)
+						} else if coef < 0 && newBnd > colLo[icol] + Featol {
+							if Cols[icol].Type == "I" {
+								newBnd = snap(newBnd, "L")
+							}
+							colLo[icol] = newBnd
+							markColChanged(icol, work)
+						}
+					} // End if remaining minimum activity is finite
+				} // End if row has upper bound
+
+				// The lower bound of the row limits the column if the maximum
+				// activity of the remaining columns is finite.
+				if Rows[i].RHSlo > -Plinfy {
+					resid, numInf = removeActivity(maxAct, maxInf, coef * bndUp, bndUp)
+
+					if numInf == 0 {
+						newBnd = (Rows[i].RHSlo - resid) / coef
+						if coef > 0 && newBnd > colLo[icol] + Featol {
+							if Cols[icol].Type == "I" {
+								newBnd = snap(newBnd, "L")
+							}
+							colLo[icol] = newBnd
+							markColChanged(icol, work)
+						} else if coef < 0 && newBnd < colUp[icol] - Featol {
+							if Cols[icol].Type == "I" {
+								newBnd = snap(newBnd, "U")
+							}
+							colUp[icol] = newBnd
+							markColChanged(icol, work)
+						}
+					} // End if remaining maximum activity is finite
+				} // End if row has lower bound
+
+				if colLo[icol] > colUp[icol] + Featol {
+					*infeasible = true
+					return nil
+				}
+			} // End for all elements in row
+		} // End for all rows checked
+	} // End for all rounds
+
+	return nil
+}
+
+//==============================================================================
+
+// markColChanged adds the column specified by colIndex to the list of changed
+// columns in work, if it is not already listed, and adds every row in which the
+// column has a non-zero element to the list of rows checked in the next round.
+func markColChanged(colIndex int, work *psProbeWork) {
+	var rowIndex int  // index of row containing the column
+
+	if !work.ColMark[colIndex] {
+		work.ColMark[colIndex] = true
+		work.Changed = append(work.Changed, colIndex)
+	}
+
+	for j := 0; j < len(Cols[colIndex].HasElems); j++ {
+		rowIndex = Elems[Cols[colIndex].HasElems[j]].InRow
+		if !work.RowMark[rowIndex] {
+			work.RowMark[rowIndex] = true
+			work.NextRows = append(work.NextRows, rowIndex)
+		}
+	}
+}
+
+//==============================================================================
+
+// resetProbe restores the bounds of the columns listed as changed in work to
+// those of the model in both pairs of bound lists passed in (lo0, up0 and lo1,
+// up1), and empties the list. Both pairs are restored, since the bounds of the
+// model may have been changed from either of them.
+func resetProbe(lo0 []float64, up0 []float64, lo1 []float64, up1 []float64,
+	work *psProbeWork) {
+
+	for _, k := range work.Changed {
+		lo0[k], up0[k] = Cols[k].BndLo, Cols[k].BndUp
+		lo1[k], up1[k] = Cols[k].BndLo, Cols[k].BndUp
+		work.ColMark[k] = false
+	}
+
+	work.Changed = work.Changed[:0]
+}
+
+//==============================================================================
+
+// setColBounds replaces the bounds of the columns listed in colList by those
+// passed in (colLo, colUp) wherever they are tighter, and increments numChgd by
+// the number of bounds changed.
+func setColBounds(colLo []float64, colUp []float64, colList []int, numChgd *int) {
+	var prevBnd float64  // bound of column before it was tightened
+
+	for _, j := range colList {
+		if colLo[j] > Cols[j].BndLo + Featol {
+			prevBnd = Cols[j].BndLo
+			Cols[j].BndLo = colLo[j]
+			recBndStep(j, "L", prevBnd, -1, "")
+			*numChgd++
+		}
+
+		if colUp[j] < Cols[j].BndUp - Featol {
+			prevBnd = Cols[j].BndUp
+			Cols[j].BndUp = colUp[j]
+			recBndStep(j, "U", prevBnd, -1, "")
+			*numChgd++
+		}
+
+		// Guard against reversal caused by tolerances.
+		if Cols[j].BndLo > Cols[j].BndUp {
+			Cols[j].BndUp = Cols[j].BndLo
+		}
+	} // End for all columns listed
+}
+
+//==============================================================================
+
+// addProbeImpls adds to the list of implications the bounds of the columns listed
+// as changed in work which are still tighter than those of the model, as they are
+// implied by the binary column specified by binIndex taking the value binVal.
+func addProbeImpls(binIndex int, binVal float64, colLo []float64, colUp []float64,
+	work *psProbeWork) {
+	var implItem PsImpl  // implication being added to the list
+
+	sort.Ints(work.Changed)
+
+	for _, k := range work.Changed {
+		if k == binIndex || Cols[k].State == stateDelete {
+			continue
+		}
+
+		if colLo[k] > Cols[k].BndLo + Featol || colUp[k] < Cols[k].BndUp - Featol {
+			implItem = PsImpl{BinCol: Cols[binIndex].Name, BinVal: binVal, Col: Cols[k].Name,
+				BndLo: math.Max(colLo[k], Cols[k].BndLo), BndUp: math.Min(colUp[k], Cols[k].BndUp)}
+			psImplList = append(psImplList, implItem)
+		}
+	} // End for all columns changed
+}
+
+//==============================================================================
+
+// probeBinaries fixes each binary column in turn to 0 and to 1, and propagates the
+// effect of the fixing on the bounds of the other columns. If one of the values
+// is infeasible, the column is fixed at the other one and the bounds derived from
+// it apply to the whole model. If both values are feasible, each column is bounded
+// by the weaker of the two bounds derived, and bounds which are tighter than that
+// are recorded as implications of the binary column taking that value. Only the
+// columns reached by a probe are visited and restored afterwards. At most
+// maxProbes columns are probed (0 for all), and probing stops once timeLimit
+// seconds have elapsed (0 for no limit).
+// The function passes back the number of bounds changed in the numChgd variable.
+// The function does nothing if the model is not a MIP.
+// In case of failure, function returns an error. If neither value of a binary
+// column is feasible, the cause of the error is a *PsInfeasError.
+func probeBinaries(maxProbes int, timeLimit float64, numChgd *int) error {
+	var lo0, up0     []float64  // column bounds derived with the binary column at 0
+	var lo1, up1     []float64  // column bounds derived with the binary column at 1
+	var work0, work1 *psProbeWork  // rows and columns reached with the column at 0 and 1
+	var infeas0           bool  // true if the binary column cannot be 0
+	var infeas1           bool  // true if the binary column cannot be 1
+	var prevBnd        float64  // bound of column before it was tightened
+	var numProbes          int  // number of columns probed
+	var numFixed           int  // number of binary columns fixed
+	var err              error  // error received from called functions
+
+	*numChgd   = 0
+	psImplList = nil
+
+	if !isMip() {
+		return nil
+	}
+
+	log(pINFO, "Probing binary variables...\n")
+
+	startTime := time.Now()
+
+	// The bound lists hold the bounds of the model, except while a column is
+	// being probed, after which the columns changed are restored.
+	lo0 = make([]float64, len(Cols))
+	up0 = make([]float64, len(Cols))
+	lo1 = make([]float64, len(Cols))
+	up1 = make([]float64, len(Cols))
+
+	for k := 0; k < len(Cols); k++ {
+		lo0[k], up0[k] = Cols[k].BndLo, Cols[k].BndUp
+		lo1[k], up1[k] = Cols[k].BndLo, Cols[k].BndUp
+	}
+
+	work0 = newProbeWork()
+	work1 = newProbeWork()
+
+	for j := 0; j < len(Cols); j++ {
+
+		if Cols[j].State == stateDelete || !isBinary(j) {
+			continue
+		}
+
+		if maxProbes > 0 && numProbes >= maxProbes {
+			log(pDEB, "  Probing stopped after %d columns.\n", numProbes)
+			break
+		}
+
+		if timeLimit > 0 && time.Since(startTime).Seconds() > timeLimit {
+			log(pDEB, "  Probing stopped after %d columns, time limit reached.\n", numProbes)
+			break
+		}
+
+		numProbes++
+
+		lo0[j], up0[j] = 0, 0
+		lo1[j], up1[j] = 1, 1
+
+		if err = propagateBounds(lo0, up0, j, psProbeRounds, work0, &infeas0); err != nil {
+			return errors.Wrap(err, "probeBinaries failed")
+		}
+
+		if err = propagateBounds(lo1, up1, j, psProbeRounds, work1, &infeas1); err != nil {
+			return errors.Wrap(err, "probeBinaries failed")
+		}
+
+		if infeas0 && infeas1 {
+			log(pERR, "ERROR: Infeasible, no feasible value for binary col %s.\n", Cols[j].Name)
+
+			// The propagation is not recorded, so the chain lists the derivations
+			// of the bounds of the columns it changed.
+			roots := colBndRoots(Cols[j].Name)
+			for _, k := range work0.Changed {
+				roots = append(roots, colBndRoots(Cols[k].Name)...)
+			}
+			for _, k := range work1.Changed {
+				roots = append(roots, colBndRoots(Cols[k].Name)...)
+			}
+			return psInfeasible(fmt.Sprintf("probeBinaries infeasible, no feasible value for %s",
+				Cols[j].Name), "", Cols[j].Name, roots)
+		}
+
+		if infeas0 || infeas1 {
+			if infeas0 {
+				setColBounds(lo1, up1, work1.Changed, numChgd)
+			} else {
+				setColBounds(lo0, up0, work0.Changed, numChgd)
+			}
+
+			log(pDEB, "  Col %s fixed at %f by probing.\n", Cols[j].Name, Cols[j].BndLo)
+			numFixed++
+		} else {
+
+			// Both values are feasible, so each column must lie within the
+			// weaker of the bounds derived for the two values. A column changed
+			// by only one of the probes keeps the bounds of the model.
+			for _, k := range work0.Changed {
+				if k == j || !work1.ColMark[k] {
+					continue
+				}
+
+				if math.Min(lo0[k], lo1[k]) > Cols[k].BndLo + Featol {
+					prevBnd = Cols[k].BndLo
+					Cols[k].BndLo = math.Min(lo0[k], lo1[k])
+					recBndStep(k, "L", prevBnd, -1, "")
+					*numChgd++
+				}
+
+				if math.Max(up0[k], up1[k]) < Cols[k].BndUp - Featol {
+					prevBnd = Cols[k].BndUp
+					Cols[k].BndUp = math.Max(up0[k], up1[k])
+					recBndStep(k, "U", prevBnd, -1, "")
+					*numChgd++
+				}
+			} // End for all columns changed
+
+			// Any bound still tighter than the one in the model is implied by
+			// the value of the binary column.
+			addProbeImpls(j, 0, lo0, up0, work0)
+			addProbeImpls(j, 1, lo1, up1, work1)
+		} // End if both values feasible
+
+		// Every bound of the model changed above belongs to a column listed by
+		// one of the probes, so restoring the columns of both probes in both
+		// pairs of bound lists brings them back in line with the model.
+		resetProbe(lo0, up0, lo1, up1, work0)
+		resetProbe(lo0, up0, lo1, up1, work1)
+
+	} // End for all columns probed
+
+	log(pINFO, "Probed %d binary columns, fixed %d, changed %d bounds, found %d implications.\n",
+		numProbes, numFixed, *numChgd, len(psImplList))
+
+	return nil
+}
+
+//==============================================================================
+// CLIQUE FUNCTIONS
+//==============================================================================
+
+// isCliqueRow returns true if the row specified by rowIndex is a multiple choice
+// row, i.e. the sum of binary variables with coefficients of 1 is at most 1
+// ("L" row), or exactly 1 ("E" row), and sets the equal flag accordingly. The
+// secondary type of the row set by AdjustModel is updated to match, as presolve
+// may have changed the bounds of its columns since.
+func isCliqueRow(rowIndex int, equal *bool) bool {
+	var index int  // index of element being processed
+
+	*equal = false
+
+	if Rows[rowIndex].State != stateActive || Rows[rowIndex].RHSup != 1.0 ||
+		len(Rows[rowIndex].HasElems) < 2 {
+		return false
+	}
+
+	if Rows[rowIndex].Type == "E" {
+		*equal = true
+	} else if Rows[rowIndex].Type != "L" {
+		return false
+	}
+
+	for j := 0; j < len(Rows[rowIndex].HasElems); j++ {
+		index = Rows[rowIndex].HasElems[j]
+		if Elems[index].Value != 1.0 || !isBinary(Elems[index].InCol) {
+			if Rows[rowIndex].SecType == conTypeMc0 || Rows[rowIndex].SecType == conTypeMc1 {
+				Rows[rowIndex].SecType = conTypeNone
+			}
+			return false
+		}
+	} // End for all elements in row
+
+	if *equal {
+		Rows[rowIndex].SecType = conTypeMc1
+	} else {
+		Rows[rowIndex].SecType = conTypeMc0
+	}
+
+	return true
+}
+
+//==============================================================================
+
+// findCliques builds the conflict graph (conflicts) of the binary columns of the
+// model, in which two columns are adjacent if they cannot both take the value 1.
+// The cliques are taken from all multiple choice rows of the model, and each pair
+// of columns found to be in conflict during the most recent probing is added
+// explicitly. The pairs of columns in the same clique row are not stored, but
+// found through the lists of cliques of the columns by inConflict.
+// In case of failure, function returns an error.
+func findCliques(conflicts *psConflicts) error {
+	var clique    psClique  // clique being added to the list
+	var equal         bool  // true if clique row is an equality
+	var colIndex       int  // index of column being processed
+	var binIndex       int  // index of binary column of implication
+	var ok            bool  // true if item was found in a map
+	var colMap map[string]int  // map of column names to indices
+
+	*conflicts = psConflicts{ColCliques: make(map[int][]int), Pairs: make(map[int]map[int]bool)}
+
+	for i := 0; i < len(Rows); i++ {
+		if !isCliqueRow(i, &equal) {
+			continue
+		}
+
+		clique = psClique{Row: i, Equal: equal}
+		for j := 0; j < len(Rows[i].HasElems); j++ {
+			clique.Cols = append(clique.Cols, Elems[Rows[i].HasElems[j]].InCol)
+		}
+		sort.Ints(clique.Cols)
+
+		for _, col := range clique.Cols {
+			conflicts.ColCliques[col] = append(conflicts.ColCliques[col], len(conflicts.Cliques))
+		}
+
+		conflicts.Cliques = append(conflicts.Cliques, clique)
+	} // End for all rows
+
+	// Setting a binary column to 1 which implies that another one is 0 is a
+	// conflict between the two.
+	colMap = make(map[string]int)
+	for j := 0; j < len(Cols); j++ {
+		colMap[Cols[j].Name] = j
+	}
+
+	for i := 0; i < len(psImplList); i++ {
+		if psImplList[i].BinVal != 1 || psImplList[i].BndUp != 0 {
+			continue
+		}
+
+		if colIndex, ok = colMap[psImplList[i].Col]; !ok || !isBinary(colIndex) {
+			continue
+		}
+
+		if binIndex, ok = colMap[psImplList[i].BinCol]; !ok || !isBinary(binIndex) {
+			continue
+		}
+
+		if conflicts.Pairs[binIndex] == nil {
+			conflicts.Pairs[binIndex] = make(map[int]bool)
+		}
+		if conflicts.Pairs[colIndex] == nil {
+			conflicts.Pairs[colIndex] = make(map[int]bool)
+		}
+		conflicts.Pairs[binIndex][colIndex] = true
+		conflicts.Pairs[colIndex][binIndex] = true
+	} // End for all implications
+
+	return nil
+}
+
+//==============================================================================
+
+// inConflict returns true if the columns specified by col1 and col2 are adjacent
+// in the conflict graph, i.e. they are in the same clique or were found to be in
+// conflict by probing.
+func inConflict(conflicts *psConflicts, col1 int, col2 int) bool {
+
+	return conflicts.Pairs[col1][col2] || sharedClique(conflicts, col1, col2)
+}
+
+//==============================================================================
+
+// sharedClique returns true if the columns specified by col1 and col2 are in the
+// same clique of the conflict graph. The cliques of the column belonging to fewer
+// of them are searched for the other column.
+func sharedClique(conflicts *psConflicts, col1 int, col2 int) bool {
+	var cliques []int  // cliques of the column belonging to fewer of them
+	var other     int  // column searched for in the cliques
+	var k         int  // position of column in a clique
+
+	cliques, other = conflicts.ColCliques[col1], col2
+	if len(conflicts.ColCliques[col2]) < len(cliques) {
+		cliques, other = conflicts.ColCliques[col2], col1
+	}
+
+	for _, c := range cliques {
+		k = sort.SearchInts(conflicts.Cliques[c].Cols, other)
+		if k < len(conflicts.Cliques[c].Cols) && conflicts.Cliques[c].Cols[k] == other {
+			return true
+		}
+	}
+
+	return false
+}
+
+//==============================================================================
+
+// conflictCols returns the sorted list of columns adjacent to the column
+// specified by colIndex in the conflict graph.
+func conflictCols(conflicts *psConflicts, colIndex int) []int {
+	var cols    []int  // columns adjacent to the column
+	var seen map[int]bool  // columns already in the list
+
+	seen = map[int]bool{colIndex: true}
+
+	for _, c := range conflicts.ColCliques[colIndex] {
+		for _, col := range conflicts.Cliques[c].Cols {
+			if !seen[col] {
+				seen[col] = true
+				cols = append(cols, col)
+			}
+		}
+	}
+
+	for col := range conflicts.Pairs[colIndex] {
+		if !seen[col] {
+			seen[col] = true
+			cols = append(cols, col)
+		}
+	}
+
+	sort.Ints(cols)
+	return cols
+}
+
+//==============================================================================
+
+// addCliqueCol adds the column specified by colIndex to the clique specified by
+// cliqueIndex, keeping the columns of the clique sorted.
+func addCliqueCol(conflicts *psConflicts, cliqueIndex int, colIndex int) {
+	var cols []int  // columns of the clique
+	var k      int  // position of the column in the clique
+
+	cols = conflicts.Cliques[cliqueIndex].Cols
+	k    = sort.SearchInts(cols, colIndex)
+	cols = append(cols, 0)
+	copy(cols[k+1:], cols[k:])
+	cols[k] = colIndex
+
+	conflicts.Cliques[cliqueIndex].Cols = cols
+	conflicts.ColCliques[colIndex] = append(conflicts.ColCliques[colIndex], cliqueIndex)
+}
+
+//==============================================================================
+
+// isSubset returns true if all items of the sorted list sub are present in the
+// sorted list set.
+func isSubset(sub []int, set []int) bool {
+	var j int  // index into set
+
+	for i := 0; i < len(sub); i++ {
+		for j < len(set) && set[j] < sub[i] {
+			j++
+		}
+		if j == len(set) || set[j] != sub[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+//==============================================================================
+
+// mergeCliques extends and merges the multiple choice (clique) rows of a MILP
+// model. A binary column in conflict with every column of a clique row is added
+// to an "L" row, extending the clique, or fixed at 0 if the row is an "E" row
+// since setting it to 1 would leave no column of the row able to take the value 1.
+// A clique row whose columns are all part of another clique row is then implied
+// by the larger one, and is removed, unless it is an equality, in which case the
+// columns of the larger row which are not in the smaller one are fixed at 0 and
+// the larger row is removed instead. Removed rows are added to the presolve list
+// as redundant rows, and extended rows as they were before the extension. The
+// clique table available through GetCliques is rebuilt at the end of the pass.
+// The function passes back the number of rows extended or deleted and columns
+// fixed in the numChgd variable. The function does nothing if the model is not
+// a MIP.
+// In case of failure, function returns an error.
+func mergeCliques(numChgd *int) error {
+	var conflicts psConflicts  // conflict graph of binary columns
+	var cands          []int  // columns in conflict with every column of a clique
+	var members        []int  // columns of the clique before it is extended
+	var small, large psClique  // smaller and larger of two cliques compared
+	var order         []int  // indices of cliques in increasing order of size
+	var position      []int  // position of each clique in order
+	var supersets     []int  // cliques which may contain the smaller clique
+	var rarest          int  // column of smaller clique in the fewest cliques
+	var isAdj           bool  // true if column is in conflict with whole clique
+	var rowExt          bool  // true if row has already been added to presolve list
+	var prevBnd      float64  // bound of column before it was fixed
+	var numExt           int  // number of clique rows extended
+	var numFixed         int  // number of columns fixed
+	var numDltd          int  // number of rows deleted
+	var err            error  // error received from called functions
+
+	*numChgd     = 0
+	psCliqueList = nil
+
+	if !isMip() {
+		return nil
+	}
+
+	log(pINFO, "Extending and merging clique rows...\n")
+
+	if err = findCliques(&conflicts); err != nil {
+		return errors.Wrap(err, "mergeCliques failed")
+	}
+
+	// Extend each clique by the columns in conflict with all of its columns. An
+	// extended clique is still a clique, so the columns added to it are adjacent
+	// to its other columns when the following cliques are extended.
+	for c := 0; c < len(conflicts.Cliques); c++ {
+		members = append([]int(nil), conflicts.Cliques[c].Cols...)
+
+		cands = nil
+		for _, col := range conflictCols(&conflicts, members[0]) {
+			if !isSubset([]int{col}, members) {
+				cands = append(cands, col)
+			}
+		}
+		rowExt = false
+
+		for _, col := range cands {
+			if Cols[col].BndUp == 0 {
+				continue
+			}
+
+			isAdj = true
+			for _, member := range conflicts.Cliques[c].Cols {
+				if !inConflict(&conflicts, col, member) {
+					isAdj = false
+					break
+				}
+			}
+			if !isAdj {
+				continue
+			}
+
+			if conflicts.Cliques[c].Equal {
+				prevBnd = Cols[col].BndUp
+				Cols[col].BndUp = 0
+				recBndStep(col, "U", prevBnd, -1, "")
+				log(pDEB, "  Col %s fixed at 0 by clique row %s.\n", Cols[col].Name, 
+					Rows[conflicts.Cliques[c].Row].Name)
+				numFixed++
+				continue
+			}
+
+			if !rowExt {
+				_ = updatePsList(psopCliqueExt, conflicts.Cliques[c].Row, -1)
+				rowExt = true
+				numExt++
+			}
+
+			if err = addElem(conflicts.Cliques[c].Row, col, 1.0); err != nil {
+				return errors.Wrap(err, "mergeCliques failed")
+			}
+
+			log(pDEB, "  Col %s added to clique row %s.\n", Cols[col].Name, 
+				Rows[conflicts.Cliques[c].Row].Name)
+			addCliqueCol(&conflicts, c, col)
+		} // End for all candidate columns
+	} // End for all cliques
+
+	// Remove clique rows implied by larger ones. Cliques are compared from the
+	// smallest to the largest, and only with the cliques which contain the column
+	// of the smaller clique belonging to the fewest cliques, since any clique
+	// containing the smaller one must contain that column.
+	order = make([]int, len(conflicts.Cliques))
+	for c := 0; c < len(order); c++ {
+		order[c] = c
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return len(conflicts.Cliques[order[i]].Cols) < len(conflicts.Cliques[order[j]].Cols)
+	})
+
+	position = make([]int, len(order))
+	for p := 0; p < len(order); p++ {
+		position[order[p]] = p
+	}
+
+	for p := 0; p < len(order); p++ {
+		small = conflicts.Cliques[order[p]]
+
+		rarest = small.Cols[0]
+		for _, col := range small.Cols {
+			if len(conflicts.ColCliques[col]) < len(conflicts.ColCliques[rarest]) {
+				rarest = col
+			}
+		}
+
+		supersets = supersets[:0]
+		for _, d := range conflicts.ColCliques[rarest] {
+			if position[d] > p {
+				supersets = append(supersets, d)
+			}
+		}
+		sort.Slice(supersets, func(i, j int) bool {
+			return position[supersets[i]] < position[supersets[j]]
+		})
+
+		for _, d := range supersets {
+			large = conflicts.Cliques[d]
+
+			if Rows[small.Row].State == stateDelete || Rows[large.Row].State == stateDelete {
+				continue
+			}
+
+			if !isSubset(small.Cols, large.Cols) {
+				continue
+			}
+
+			if !small.Equal {
+				Rows[small.Row].State = stateDelete
+				_ = updatePsList(psopRedRow, small.Row, -1)
+				log(pDEB, "  Row %s removed, implied by clique row %s.\n", 
+					Rows[small.Row].Name, Rows[large.Row].Name)
+				continue
+			}
+
+			// The smaller row is an equality, so the columns in the larger row
+			// which are not in the smaller one can never take the value 1.
+			for _, col := range large.Cols {
+				k := sort.SearchInts(small.Cols, col)
+				if (k == len(small.Cols) || small.Cols[k] != col) && Cols[col].BndUp != 0 {
+					prevBnd = Cols[col].BndUp
+					Cols[col].BndUp = 0
+					recBndStep(col, "U", prevBnd, -1, "")
+					numFixed++
+				}
+			}
+
+			Rows[large.Row].State = stateDelete
+			_ = updatePsList(psopRedRow, large.Row, -1)
+			log(pDEB, "  Row %s removed, implied by clique row %s.\n", 
+				Rows[large.Row].Name, Rows[small.Row].Name)
+		} // End for all larger cliques
+	} // End for all cliques
+
+	if err = delTaggedRows(&numDltd); err != nil {
+		return errors.Wrap(err, "mergeCliques failed")
+	}
+
+	if numExt != 0 {
+		if err = calcGradVec(); err != nil {
+			return errors.Wrap(err, "mergeCliques failed")
+		}
+	}
+
+	if err = buildCliqueTable(); err != nil {
+		return errors.Wrap(err, "mergeCliques failed")
+	}
+
+	*numChgd = numExt + numFixed + numDltd
+
+	if *numChgd != 0 {
+		log(pINFO, "Extended %d clique rows, deleted %d rows, fixed %d variables.\n",
+			numExt, numDltd, numFixed)
+	}
+
+	return nil
+}
+
+//==============================================================================
+
+// buildCliqueTable rebuilds the clique table available through GetCliques from
+// the multiple choice rows currently in the model, and the conflicts between
+// pairs of binary columns found by probing which are not part of any such row.
+// In case of failure, function returns an error.
+func buildCliqueTable() error {
+	var conflicts psConflicts  // conflict graph of binary columns
+	var tableItem    PsClique  // item being added to the clique table
+	var confCols        []int  // columns with conflicts found by probing
+	var others          []int  // columns in conflict with the one being processed
+	var err             error  // error received from called functions
+
+	psCliqueList = nil
+
+	if err = findCliques(&conflicts); err != nil {
+		return errors.Wrap(err, "buildCliqueTable failed")
+	}
+
+	for c := 0; c < len(conflicts.Cliques); c++ {
+		tableItem = PsClique{Equal: conflicts.Cliques[c].Equal, 
+			Row: Rows[conflicts.Cliques[c].Row].Name}
+		for _, col := range conflicts.Cliques[c].Cols {
+			tableItem.Cols = append(tableItem.Cols, Cols[col].Name)
+		}
+		psCliqueList = append(psCliqueList, tableItem)
+	} // End for all clique rows
+
+	// Only the pairs found by probing are visited, in increasing order of the
+	// columns so that the table does not depend on the order of the maps. A pair
+	// already in a clique row is covered by that row.
+	for col1 := range conflicts.Pairs {
+		confCols = append(confCols, col1)
+	}
+	sort.Ints(confCols)
+
+	for _, col1 := range confCols {
+		others = others[:0]
+		for col2 := range conflicts.Pairs[col1] {
+			if col2 > col1 {
+				others = append(others, col2)
+			}
+		}
+		sort.Ints(others)
+
+		for _, col2 := range others {
+			if sharedClique(&conflicts, col1, col2) {
+				continue
+			}
+			tableItem = PsClique{Cols: []string{Cols[col1].Name, Cols[col2].Name}}
+			psCliqueList = append(psCliqueList, tableItem)
+		}
+	} // End for all columns with conflicts found by probing
+
+	return nil
+}
+
+//==============================================================================
+// EXPORTED FUNCTIONS
+//==============================================================================
+
+// GetImplications returns in implList the implications between variables found
+// during the most recent probing of binary variables by ReduceMatrix. Columns
+// are referenced by name, as some of them may have been removed by presolve.
+// In case of failure, function returns an error.
+func GetImplications(implList *[]PsImpl) error {
+
+	*implList = make([]PsImpl, len(psImplList))
+	copy(*implList, psImplList)
+
+	return nil
+}
+
+//==============================================================================
+
+// GetCliques returns in cliqueList the clique table built during the most recent
+// clique pass of ReduceMatrix. Each clique lists binary columns by name, at most
+// one of which (or exactly one, if Equal is set) can take the value 1.
+// In case of failure, function returns an error.
+func GetCliques(cliqueList *[]PsClique) error {
+
+	*cliqueList = make([]PsClique, len(psCliqueList))
+	for i := 0; i < len(psCliqueList); i++ {
+		(*cliqueList)[i] = psCliqueList[i]
+		(*cliqueList)[i].Cols = append([]string(nil), psCliqueList[i].Cols...)
+	}
+
+	return nil
+}
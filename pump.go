@@ -0,0 +1,557 @@
+//==============================================================================
+// pump: feasibility PUMP heuristic for MILP models
+// 01   Oct. 18, 2026   Initial version
+
+
+// This file contains the feasibility pump of Fischetti, Glover and Lodi, which
+// searches for an integer feasible point of the MILP model in the Rows, Cols, and
+// Elems global variables, which may be the model as read or the model reduced by
+// ReduceMatrix.
+//
+// Starting from the solution of the LP relaxation, the pump alternates between
+// rounding the integer columns of the LP solution to obtain a target point, and
+// solving an LP which finds the point of the LP relaxation closest to the target,
+// measured by the L1 distance over the integer columns. Integer columns at a bound
+// of the target are measured directly, while others need an auxiliary column and
+// two rows. When the rounded point repeats the target, the integer columns furthest
+// from their target are moved by one, and when the new target, moved or not,
+// repeats one of the recent targets, all integer columns are perturbed at random
+// and the pump restarts. The pump stops when the LP solution is integer, or when
+// the iteration or time limit is reached.
+
+package lpo
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+
+// LpSolver is the type of the functions which solve the LP relaxation of the model
+// in the Rows, Cols, and Elems global variables for FeasibilityPump. The function
+// must set the Status of the solution passed to it and, if the model was solved
+// to optimality, the values of the columns in ColValue. SimplexSolve, CoinLpSolve
+// and CplexLpSolve can be used.
+type LpSolver func(soln *SpxSoln) error
+
+// Reasons why FeasibilityPump stopped
+const (
+	FpStopFound      = "FOUND"       // Integer feasible point found
+	FpStopInfeasible = "INFEASIBLE"  // LP relaxation is infeasible
+	FpStopMaxIter    = "MAX_ITER"    // Maximum number of iterations reached
+	FpStopTime       = "TIME"        // Time limit reached
+)
+
+// Constants used by FeasibilityPump
+const (
+	fpDefMaxIter = 1000  // Default maximum number of iterations
+	fpFlipNum    = 10    // Average number of columns moved when the target repeats
+	fpHistLen    = 20    // Number of earlier targets checked for cycles
+)
+
+// FpCtrl contains the parameters of FeasibilityPump. If MaxIter is zero, a
+// default of 1000 iterations is used. If Solver is nil, SimplexSolve is used if
+// the largest LP solved by the pump is small enough for it, and FeasibilityPump
+// returns an error otherwise.
+type FpCtrl struct {
+	MaxIter    int       // Maximum number of iterations
+	TimeLimit  float64   // Maximum time in seconds, or 0 for no limit
+	Seed       int64     // Seed of the random perturbations
+	Solver     LpSolver  // Function solving the LP relaxations
+}
+
+// FpResult contains the results of FeasibilityPump. If an integer feasible point
+// was found, Point holds its values in the same order as Cols, and Soln the point
+// completed to the original model by postsolve if ReduceMatrix was run, or the
+// point of the model as loaded otherwise.
+type FpResult struct {
+	Found      bool           // True if an integer feasible point was found
+	StopReason string         // FpStopFound, FpStopInfeasible, FpStopMaxIter, or FpStopTime
+	Iter       int            // Number of iterations performed
+	NumFlips   int            // Number of times columns were moved because the target repeated
+	NumRestart int            // Number of restarts after a cycle was found
+	Time       time.Duration  // Time spent
+	Point      []float64      // Integer feasible point of the model as loaded
+	Soln       PsSoln         // Integer feasible point of the original model
+}
+
+//==============================================================================
+
+// pumpBuildModel replaces the model in the global variables by the LP solved by
+// the feasibility pump, made from the saved model with all columns continuous. If
+// target is nil, the objective function of the saved model is kept if useObj is
+// true, and removed otherwise. If target is given, the objective function is the
+// distance of the integer columns (isInt) from the target, and auxiliary columns
+// and rows are added after those of the saved model for integer columns whose
+// target is not at a bound.
+func pumpBuildModel(saved modelSnapshot, target []float64, isInt []bool, useObj bool) {
+	var newRow InputRow  // row being added to the model
+	var auxCol     int   // index of auxiliary column
+
+	Rows        = make([]InputRow, len(saved.rows))
+	Cols        = make([]InputCol, len(saved.cols))
+	Elems       = nil
+	ObjRow      = saved.objRow
+	objRowConst = 0
+	Name        = saved.name
+
+	copy(Rows, saved.rows)
+	copy(Cols, saved.cols)
+
+	if useObj && target == nil {
+		objRowConst = saved.objConst
+	}
+
+	for j := 0; j < len(Cols); j++ {
+		Cols[j].Type = "R"
+	}
+
+	if ObjRow < 0 {
+		ObjRow = len(Rows)
+		Rows   = append(Rows, InputRow{Name: "OBJ", State: stateLocked, Type: "N", ScaleFactor: 1})
+	}
+
+	for i := 0; i < len(saved.elems); i++ {
+		if saved.elems[i].InRow != ObjRow || (useObj && target == nil) {
+			Elems = append(Elems, saved.elems[i])
+		}
+	}
+
+	// Add the distance from the target to the objective function.
+	for j := 0; target != nil && j < len(saved.cols); j++ {
+		if !isInt[j] {
+			continue
+		}
+
+		switch {
+		case target[j] <= Cols[j].BndLo:
+			Elems = append(Elems, InputElem{InRow: ObjRow, InCol: j, Value: 1})
+
+		case target[j] >= Cols[j].BndUp:
+			Elems = append(Elems, InputElem{InRow: ObjRow, InCol: j, Value: -1})
+
+		default:
+			// The auxiliary column is at least the distance in either direction.
+			auxCol = len(Cols)
+			Cols   = append(Cols, InputCol{Name: fmt.Sprintf("_fpd%d", j), State: stateActive,
+				Type: "R", BndLo: 0, BndUp: Plinfy, ScaleFactor: 1})
+			Elems  = append(Elems, InputElem{InRow: ObjRow, InCol: auxCol, Value: 1})
+
+			newRow = InputRow{Name: fmt.Sprintf("_fpl%d", j), State: stateActive, Type: "G",
+				RHSlo: -target[j], RHSup: Plinfy, ScaleFactor: 1}
+			Rows   = append(Rows, newRow)
+			Elems  = append(Elems, InputElem{InRow: len(Rows) - 1, InCol: auxCol, Value: 1},
+				InputElem{InRow: len(Rows) - 1, InCol: j, Value: -1})
+
+			newRow.Name  = fmt.Sprintf("_fpu%d", j)
+			newRow.RHSlo = target[j]
+			Rows   = append(Rows, newRow)
+			Elems  = append(Elems, InputElem{InRow: len(Rows) - 1, InCol: auxCol, Value: 1},
+				InputElem{InRow: len(Rows) - 1, InCol: j, Value: 1})
+		}
+	} // End for all integer columns
+
+	// Rebuild the lists of elements of the rows and columns.
+	for i := 0; i < len(Rows); i++ {
+		Rows[i].HasElems = nil
+	}
+
+	for j := 0; j < len(Cols); j++ {
+		Cols[j].HasElems = nil
+	}
+
+	for k := 0; k < len(Elems); k++ {
+		Rows[Elems[k].InRow].HasElems = append(Rows[Elems[k].InRow].HasElems, k)
+		Cols[Elems[k].InCol].HasElems = append(Cols[Elems[k].InCol].HasElems, k)
+	}
+}
+
+//==============================================================================
+
+// pumpSolve solves the LP built by pumpBuildModel with the solver passed to the
+// function (solver), and returns the values of the columns of the saved model in
+// point. The status of the solution is returned in status.
+// In case of failure, function returns an error.
+func pumpSolve(solver LpSolver, point []float64, status *string) error {
+	var soln SpxSoln  // solution of the LP
+
+	if err := solver(&soln); err != nil {
+		return errors.Wrap(err, "pumpSolve failed")
+	}
+
+	*status = soln.Status
+	if soln.Status != SpxOptimal {
+		return nil
+	}
+
+	if len(soln.ColValue) < len(point) {
+		return errors.Errorf("pumpSolve received %d values, expected %d",
+			len(soln.ColValue), len(point))
+	}
+
+	copy(point, soln.ColValue)
+
+	return nil
+}
+
+//==============================================================================
+
+// pumpRound returns the value passed to the function (value) rounded to the
+// nearest integer within the bounds (lo, up).
+func pumpRound(value float64, lo float64, up float64) float64 {
+
+	value = math.Round(value)
+
+	if value < lo {
+		value = math.Ceil(lo - Featol)
+	}
+
+	if value > up {
+		value = math.Floor(up + Featol)
+	}
+
+	return value
+}
+
+//==============================================================================
+
+// pumpFeasible returns true if the point passed to the function (point) satisfies
+// the rows and column bounds of the saved model within Featol. The LHS of the rows
+// is calculated in lhs, passed in to avoid allocating memory.
+func pumpFeasible(saved modelSnapshot, point []float64, lhs []float64) bool {
+
+	for i := 0; i < len(lhs); i++ {
+		lhs[i] = 0
+	}
+
+	for k := 0; k < len(saved.elems); k++ {
+		lhs[saved.elems[k].InRow] += saved.elems[k].Value * point[saved.elems[k].InCol]
+	}
+
+	for i := 0; i < len(saved.rows); i++ {
+		if i == saved.objRow || saved.rows[i].Type == "N" {
+			continue
+		}
+		if lhs[i] < saved.rows[i].RHSlo - Featol || lhs[i] > saved.rows[i].RHSup + Featol {
+			return false
+		}
+	}
+
+	for j := 0; j < len(saved.cols); j++ {
+		if point[j] < saved.cols[j].BndLo - Featol || point[j] > saved.cols[j].BndUp + Featol {
+			return false
+		}
+	}
+
+	return true
+}
+
+//==============================================================================
+
+// pumpSoln returns in psRslt the solution made from the point passed to the
+// function (point) of the model in the global variables. If the model was reduced
+// by presolve, the point is completed to the original model by PostSolve.
+// In case of failure, function returns an error.
+func pumpSoln(point []float64, psRslt *PsSoln) error {
+	var row      psRow  // row being processed
+	var lhs    float64  // LHS of objective function
+
+	conMap := make(PsResConMap)
+	varMap := make(PsResVarMap)
+
+	for i := 0; i < len(Rows); i++ {
+		if i == ObjRow {
+			continue
+		}
+		if err := translateRow(Rows[i], &row); err != nil {
+			return errors.Wrap(err, "pumpSoln failed")
+		}
+		_ = addConMapItem(conMap, row)
+	}
+
+	for j := 0; j < len(Cols); j++ {
+		mapItem := varMap[Cols[j].Name]
+		mapItem.Status      = psVarStatNA
+		mapItem.Value       = point[j]
+		mapItem.ScaleFactor = Cols[j].ScaleFactor
+		varMap[Cols[j].Name] = mapItem
+	}
+
+	if psOrigSaved {
+		if err := PostSolve(conMap, varMap, psRslt); err != nil {
+			return errors.Wrap(err, "pumpSoln failed")
+		}
+		return nil
+	}
+
+	*psRslt = PsSoln{ConMap: conMap, VarMap: varMap}
+
+	if ObjRow >= 0 {
+		if err := translateRow(Rows[ObjRow], &row); err != nil {
+			return errors.Wrap(err, "pumpSoln failed")
+		}
+		if err := getPstLhs(row, varMap, &lhs); err != nil {
+			return errors.Wrap(err, "pumpSoln failed")
+		}
+		psRslt.ObjVal = lhs - objRowConst
+	}
+
+	return nil
+}
+
+//==============================================================================
+
+// FeasibilityPump searches for an integer feasible point of the model in the Rows,
+// Cols, and Elems global variables with the feasibility pump, using the parameters
+// in fpCtrl. The LPs are solved by the solver given in fpCtrl, which only needs to
+// solve LPs, since all columns are made continuous. The model is restored when the
+// function returns. The first integer feasible point found, the reason for
+// stopping, and the statistics of the search are returned in fpRslt.
+// In case of failure, function returns an error.
+func FeasibilityPump(fpCtrl FpCtrl, fpRslt *FpResult) error {
+	var saved   modelSnapshot  // model as passed to the function
+	var isInt   []bool     // true if column is integer
+	var intCols []int      // indices of integer columns
+	var lpPoint []float64  // solution of the last LP
+	var target  []float64  // rounded point
+	var next    []float64  // rounding of the last LP solution
+	var history [][]float64  // earlier targets
+	var lhs     []float64  // LHS of rows of the model
+	var status  string     // status of the last LP solved
+	var start   time.Time  // time the search started
+	var numFlip int        // number of columns moved
+	var integer bool       // true if the LP solution is integer
+	var cycle   bool       // true if the rounded point repeats an earlier target
+	var err     error      // error returned by secondary functions called
+
+	if fpRslt == nil {
+		return errors.New("FeasibilityPump received nil results structure")
+	}
+
+	if len(Rows) == 0 {
+		return errors.New("FeasibilityPump received empty rows list")
+	}
+
+	*fpRslt = FpResult{}
+	start   = time.Now()
+
+	if fpCtrl.MaxIter <= 0 {
+		fpCtrl.MaxIter = fpDefMaxIter
+	}
+
+	// The distance LP has up to an auxiliary column and two rows for each integer
+	// column, and an objective row if the model has none, all of which the dense
+	// tableau of SimplexSolve must hold.
+	if fpCtrl.Solver == nil {
+		numInt := 0
+		for j := 0; j < len(Cols); j++ {
+			if Cols[j].Type != "R" {
+				numInt++
+			}
+		}
+
+		if !spxFitsSize(len(Rows) + 2 * numInt + 1, len(Cols) + numInt) {
+			return errors.New("FeasibilityPump received no solver, model too large for SimplexSolve")
+		}
+
+		fpCtrl.Solver = SimplexSolve
+	}
+
+	rng := rand.New(rand.NewSource(fpCtrl.Seed))
+
+	saveModelSnapshot(&saved)
+	defer restoreModelSnapshot(saved)
+
+	isInt   = make([]bool, len(saved.cols))
+	lpPoint = make([]float64, len(saved.cols))
+	target  = make([]float64, len(saved.cols))
+	next    = make([]float64, len(saved.cols))
+	lhs     = make([]float64, len(saved.rows))
+
+	for j := 0; j < len(saved.cols); j++ {
+		if saved.cols[j].Type != "R" {
+			isInt[j] = true
+			intCols  = append(intCols, j)
+		}
+	}
+
+	log(pINFO, "\nFeasibility pump started with %d integer columns.\n", len(intCols))
+
+	// Solve the LP relaxation, or find a point of it if it is unbounded. If presolve
+	// removed all columns, the model is feasible if the empty point satisfies it.
+	status = SpxInfeasible
+	if len(saved.cols) == 0 {
+		if pumpFeasible(saved, lpPoint, lhs) {
+			status = SpxOptimal
+		}
+	} else {
+		pumpBuildModel(saved, nil, isInt, true)
+		if err = pumpSolve(fpCtrl.Solver, lpPoint, &status); err != nil {
+			return errors.Wrap(err, "FeasibilityPump failed")
+		}
+	}
+
+	if status == SpxUnbounded {
+		pumpBuildModel(saved, nil, isInt, false)
+		if err = pumpSolve(fpCtrl.Solver, lpPoint, &status); err != nil {
+			return errors.Wrap(err, "FeasibilityPump failed")
+		}
+	}
+
+	if status == SpxInfeasible {
+		fpRslt.StopReason = FpStopInfeasible
+		fpRslt.Time       = time.Since(start)
+		log(pINFO, "Feasibility pump found LP relaxation infeasible.\n")
+		return nil
+	}
+
+	if status != SpxOptimal {
+		return errors.Errorf("FeasibilityPump received LP status %s", status)
+	}
+
+	for j := 0; j < len(saved.cols); j++ {
+		if isInt[j] {
+			target[j] = pumpRound(lpPoint[j], saved.cols[j].BndLo, saved.cols[j].BndUp)
+		}
+	}
+
+	fpRslt.StopReason = FpStopMaxIter
+
+	for {
+		// Stop if the LP solution is integer and satisfies the model.
+		integer = true
+		for _, j := range intCols {
+			if math.Abs(lpPoint[j] - math.Round(lpPoint[j])) > Featol {
+				integer = false
+				break
+			}
+		}
+
+		if integer {
+			for _, j := range intCols {
+				lpPoint[j] = math.Round(lpPoint[j])
+			}
+			if pumpFeasible(saved, lpPoint, lhs) {
+				fpRslt.Found      = true
+				fpRslt.StopReason = FpStopFound
+				break
+			}
+		}
+
+		if fpRslt.Iter >= fpCtrl.MaxIter {
+			break
+		}
+
+		if fpCtrl.TimeLimit > 0 && time.Since(start).Seconds() >= fpCtrl.TimeLimit {
+			fpRslt.StopReason = FpStopTime
+			break
+		}
+
+		fpRslt.Iter++
+
+		// Find the point of the LP relaxation closest to the target.
+		pumpBuildModel(saved, target, isInt, false)
+		if err = pumpSolve(fpCtrl.Solver, lpPoint, &status); err != nil {
+			return errors.Wrap(err, "FeasibilityPump failed")
+		}
+		if status != SpxOptimal {
+			return errors.Errorf("FeasibilityPump received LP status %s", status)
+		}
+
+		for _, j := range intCols {
+			next[j] = pumpRound(lpPoint[j], saved.cols[j].BndLo, saved.cols[j].BndUp)
+		}
+
+		cycle = true
+		for _, j := range intCols {
+			if next[j] != target[j] {
+				cycle = false
+				break
+			}
+		}
+
+		if cycle {
+			// Move the integer columns furthest from the target by one towards the
+			// LP solution.
+			sort.SliceStable(intCols, func(a, b int) bool {
+				return math.Abs(lpPoint[intCols[a]] - target[intCols[a]]) >
+					math.Abs(lpPoint[intCols[b]] - target[intCols[b]])
+			})
+
+			numFlip = fpFlipNum / 2 + rng.Intn(fpFlipNum + 1)
+			for k := 0; k < numFlip && k < len(intCols); k++ {
+				j := intCols[k]
+				if math.Abs(lpPoint[j] - target[j]) <= Featol {
+					break
+				}
+				next[j] = pumpRound(target[j] + math.Copysign(1, lpPoint[j] - target[j]),
+					saved.cols[j].BndLo, saved.cols[j].BndUp)
+			}
+
+			sort.Ints(intCols)
+			fpRslt.NumFlips++
+		}
+
+		// Perturb all integer columns at random and restart if the new target,
+		// including one just moved, repeats the current or an earlier target.
+		history = append(history, append([]float64(nil), target...))
+		if len(history) > fpHistLen {
+			history = history[1:]
+		}
+
+		cycle = false
+		for h := 0; h < len(history) && !cycle; h++ {
+			cycle = true
+			for _, j := range intCols {
+				if next[j] != history[h][j] {
+					cycle = false
+					break
+				}
+			}
+		}
+
+		if cycle {
+			for _, j := range intCols {
+				rho := rng.Float64() - 0.3
+				if math.Abs(lpPoint[j] - next[j]) + math.Max(rho, 0) > 0.5 {
+					dir := math.Copysign(1, lpPoint[j] - next[j])
+					if lpPoint[j] == next[j] && rng.Intn(2) == 0 {
+						dir = -dir
+					}
+					next[j] = pumpRound(next[j] + dir, saved.cols[j].BndLo, saved.cols[j].BndUp)
+				}
+			}
+			fpRslt.NumRestart++
+		}
+
+		copy(target, next)
+
+		log(pDEB, "  Iteration %d: %d flips, %d restarts.\n", fpRslt.Iter, fpRslt.NumFlips,
+			fpRslt.NumRestart)
+	} // End for all iterations
+
+	fpRslt.Time = time.Since(start)
+
+	log(pINFO, "Feasibility pump stopped (%s) after %d iterations.\n", fpRslt.StopReason,
+		fpRslt.Iter)
+
+	if !fpRslt.Found {
+		return nil
+	}
+
+	// Restore the model and report the point found through postsolve.
+	restoreModelSnapshot(saved)
+
+	fpRslt.Point = append([]float64(nil), lpPoint...)
+	if err = pumpSoln(fpRslt.Point, &fpRslt.Soln); err != nil {
+		return errors.Wrap(err, "FeasibilityPump failed")
+	}
+
+	return nil
+}
+
+//============================ END OF FILE =====================================
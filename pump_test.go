@@ -0,0 +1,52 @@
+//==============================================================================
+// pump_test: TESTS of the feasibility pump
+// 01   Oct. 18, 2026   Initial version
+
+
+// The tests run the feasibility pump on the sample MILP model supplied with lporun
+// (p0033) with fixed seeds, and check that an integer point is found which satisfies
+// the rows and bounds of the model. The seeds used cycled between a few targets
+// when the history of targets was not checked after the columns were moved.
+
+package lpo
+
+import (
+	"fmt"
+	"testing"
+)
+
+//==============================================================================
+
+// TestPumpSmallMilp runs FeasibilityPump on p0033 with the in-process simplex
+// solver for each of the seeds listed.
+func TestPumpSmallMilp(t *testing.T) {
+	var fpRslt  FpResult  // results returned by the pump
+	var numCols int       // number of columns of the model as loaded
+
+	quietLog(t)
+
+	for _, seed := range []int64{0, 3, 4} {
+		loadModel(t, "lporun/inputSmallMilp.txt")
+		numCols = len(Cols)
+
+		if err := FeasibilityPump(FpCtrl{Seed: seed}, &fpRslt); err != nil {
+			t.Fatalf("seed %d: FeasibilityPump: %v", seed, err)
+		}
+
+		if !fpRslt.Found || fpRslt.StopReason != FpStopFound {
+			t.Errorf("seed %d: stopped (%s) after %d iterations without a point", seed,
+				fpRslt.StopReason, fpRslt.Iter)
+			continue
+		}
+
+		if len(Cols) != numCols || len(fpRslt.Point) != numCols {
+			t.Errorf("seed %d: %d columns and point of %d, want %d", seed, len(Cols),
+				len(fpRslt.Point), numCols)
+			continue
+		}
+
+		checkPoint(t, fmt.Sprintf("seed %d", seed), fpRslt.Point)
+	} // End for all seeds
+}
+
+//============================ END OF FILE =====================================
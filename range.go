@@ -0,0 +1,378 @@
+//==============================================================================
+// range: RHS and objective RANGing of LP models
+// 01   Oct. 18, 2026   Initial version
+
+
+// This file contains the functions which calculate how far the RHS of each row
+// and the objective coefficient of each column of an LP model can move before the
+// optimal basis changes, and which map the ranges of the reduced model back to the
+// rows and columns of the original model.
+//
+// The range of a row refers to its active RHS, i.e. the bound at which the row
+// is binding, or for a non-binding row the bound it would reach, given by the
+// Bound field. Ranges are calculated for the reduced model, and are mapped to the
+// original model by shifting them by the change that presolve made to the RHS or
+// objective coefficient. Rows and columns removed by presolve, and rows and
+// columns which were merged with a duplicate, have no range and are marked with
+// RangeNA. Column bounds tightened by presolve are part of the reduced model, so
+// if a bound implied by a row is active at the solution, the ranges of the column
+// and of the row are those of the reduced model and may differ from those of the
+// original model.
+
+package lpo
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"math"
+	"sort"
+)
+
+
+// Status of a range in a PsRangeMap
+const (
+	RangeOk = "OK"  // Range was calculated
+	RangeNA = "NA"  // Range is not available, e.g. item was removed by presolve
+)
+
+// PsRange contains the range of the RHS of a row or of the objective coefficient
+// of a column within which the optimal basis does not change. Infinite ends are
+// set to -Plinfy or Plinfy. If the range is not available, Value holds the RHS or
+// objective coefficient of the original model, and Lo and Up are set to zero.
+type PsRange struct {
+	Status  string   // RangeOk, or RangeNA if not available
+	Bound   string   // Active bound of a row ("L" or "U"), "" for a column
+	Value   float64  // Current RHS or objective coefficient
+	Lo      float64  // Lowest value for which the basis stays optimal
+	Up      float64  // Highest value for which the basis stays optimal
+}
+
+// PsRangeMap contains the ranges of rows or columns keyed by name.
+type PsRangeMap map[string]PsRange
+
+//==============================================================================
+
+// rangeBound returns the active bound ("L" or "U") of the row specified by index,
+// given the basis status of its logical (status): the bound at which the logical
+// is nonbasic, or for a basic logical the upper bound if finite and the lower
+// bound otherwise.
+func rangeBound(index int, status string) string {
+
+	if status == SpxAtLower || status == SpxAtUpper {
+		return status
+	}
+
+	if Rows[index].RHSup < Plinfy {
+		return SpxAtUpper
+	}
+
+	return SpxAtLower
+}
+
+//==============================================================================
+
+// rangeRhs returns the RHS of the row (row) at the bound specified (bound).
+func rangeRhs(row psRow, bound string) float64 {
+
+	if bound == SpxAtUpper {
+		return row.RhsUp
+	}
+
+	return row.RhsLo
+}
+
+//==============================================================================
+
+// rangeShift returns the end of a range (value) moved by the amount passed to the
+// function (shift), leaving infinite ends unchanged.
+func rangeShift(value float64, shift float64) float64 {
+
+	if math.Abs(value) >= Plinfy {
+		return value
+	}
+
+	return value + shift
+}
+
+//==============================================================================
+
+// rangeScale returns the end of a range (value) multiplied by the scale factor
+// passed to the function (factor), leaving infinite ends unchanged.
+func rangeScale(value float64, factor float64) float64 {
+
+	if math.Abs(value) >= Plinfy {
+		return value
+	}
+
+	return value * factor
+}
+
+//==============================================================================
+
+// RangeModel solves the LP relaxation of the model in the Rows, Cols, and Elems
+// global variables with SimplexSolve, and adds the range of the active RHS of
+// every row other than the objective function and free rows to rhsRange, and
+// the range of the objective coefficient of every column to objRange, both keyed
+// by name. The solver uses a dense tableau and is only suitable for small models.
+// In case of failure, or if the model has no optimal solution, function returns
+// an error.
+func RangeModel(rhsRange PsRangeMap, objRange PsRangeMap) error {
+	var soln SpxSoln   // solution of the model
+	var cost []float64 // objective coefficients of the columns
+	var iel       int  // index of item in elements list
+
+	if rhsRange == nil || objRange == nil {
+		return errors.New("RangeModel received nil map")
+	}
+
+	if err := SimplexSolve(&soln); err != nil {
+		return errors.Wrap(err, "RangeModel failed")
+	}
+
+	if soln.Status != SpxOptimal {
+		return errors.Errorf("RangeModel failed, solution status is %s", soln.Status)
+	}
+
+	for i := 0; i < len(Rows); i++ {
+		if i == ObjRow || Rows[i].Type == "N" {
+			continue
+		}
+
+		bound := rangeBound(i, soln.RowStat[i])
+		value := Rows[i].RHSlo
+		if bound == SpxAtUpper {
+			value = Rows[i].RHSup
+		}
+
+		rhsRange[Rows[i].Name] = PsRange{Status: RangeOk, Bound: bound, Value: value,
+			Lo: soln.RhsRangeLo[i], Up: soln.RhsRangeUp[i]}
+	} // End for all rows
+
+	cost = make([]float64, len(Cols))
+	if ObjRow >= 0 {
+		for k := 0; k < len(Rows[ObjRow].HasElems); k++ {
+			iel = Rows[ObjRow].HasElems[k]
+			cost[Elems[iel].InCol] += Elems[iel].Value
+		}
+	}
+
+	for j := 0; j < len(Cols); j++ {
+		objRange[Cols[j].Name] = PsRange{Status: RangeOk, Value: cost[j],
+			Lo: soln.ObjRangeLo[j], Up: soln.ObjRangeUp[j]}
+	}
+
+	return nil
+}
+
+//==============================================================================
+
+// rangeReduced calculates the ranges of the reduced LP model with the in-process
+// simplex solver, for solvers which do not provide them, and adds them to the
+// maps passed to the function (rhsRange, objRange). Nothing is added if the model
+// is a MILP or is too large for the in-process solver, in which case all items
+// are marked as not available by PostSolveRange.
+// In case of failure, function returns an error.
+func rangeReduced(rhsRange PsRangeMap, objRange PsRangeMap) error {
+
+	if isMip() {
+		log(pWARN, "WARNING: Ranging is not available for MILP models.\n")
+		return nil
+	}
+
+	if !spxFits() {
+		log(pWARN, "WARNING: Model too large for in-process ranging, ranges not available.\n")
+		return nil
+	}
+
+	if err := RangeModel(rhsRange, objRange); err != nil {
+		return errors.Wrap(err, "rangeReduced failed")
+	}
+
+	return nil
+}
+
+//==============================================================================
+
+// cplexRange calculates the ranges of the reduced LP model with Cplex through the
+// file interface, and adds them to the maps passed to the function (rhsRange,
+// objRange). The active bound of each row is taken to be the one closest to the
+// RHS reported by Cplex. Nothing is added if the model is a MILP, in which case
+// all items are marked as not available by PostSolveRange.
+// In case of failure, function returns an error.
+func cplexRange(rhsRange PsRangeMap, objRange PsRangeMap) error {
+	var fileName string  // MPS file of the reduced model read by Cplex
+
+	if isMip() {
+		log(pWARN, "WARNING: Ranging is not available for MILP models.\n")
+		return nil
+	}
+
+	fileName = tempDirPath + "/cplexRangeIn.txt"
+
+	if err := WriteMpsFile(fileName); err != nil {
+		return errors.Wrap(err, "cplexRange failed")
+	}
+
+	if err := CplexRangeMps(fileName, rhsRange, objRange); err != nil {
+		return errors.Wrap(err, "cplexRange failed")
+	}
+
+	for i := 0; i < len(Rows); i++ {
+		item, ok := rhsRange[Rows[i].Name]
+		if !ok {
+			continue
+		}
+
+		item.Bound = SpxAtLower
+		if math.Abs(item.Value - Rows[i].RHSup) < math.Abs(item.Value - Rows[i].RHSlo) {
+			item.Bound = SpxAtUpper
+		}
+		rhsRange[Rows[i].Name] = item
+	} // End for all rows
+
+	return nil
+}
+
+//==============================================================================
+
+// unscaleRange converts the ranges of the scaled reduced model, passed to the
+// function as rhsRange and objRange, to those of the reduced model before scaling,
+// using the factors recorded by scaleForSolver. RHS are multiplied by the row
+// factors and objective coefficients by the column factors.
+func unscaleRange(rhsRange PsRangeMap, objRange PsRangeMap) {
+
+	for name, factor := range psScaleRow {
+		if item, ok := rhsRange[name]; ok {
+			item.Value = rangeScale(item.Value, factor)
+			item.Lo    = rangeScale(item.Lo, factor)
+			item.Up    = rangeScale(item.Up, factor)
+			rhsRange[name] = item
+		}
+	}
+
+	for name, factor := range psScaleCol {
+		if item, ok := objRange[name]; ok {
+			item.Value = rangeScale(item.Value, factor)
+			item.Lo    = rangeScale(item.Lo, factor)
+			item.Up    = rangeScale(item.Up, factor)
+			objRange[name] = item
+		}
+	}
+}
+
+//==============================================================================
+
+// PostSolveRange maps the ranges of the reduced model (rhsRange, objRange), keyed
+// by name, to the rows and columns of the original model using the list of
+// pre-solve operations recorded by ReduceMatrix or read by ReadPsopFile, and
+// stores them in the RhsRange and ObjRange maps of psRslt, which must hold the
+// solution completed by PostSolve. Every row other than the objective function
+// and every column of the original model is listed. Ranges are shifted by the
+// change presolve made to the RHS or objective coefficient; items removed by
+// presolve, merged with a duplicate, or missing from the maps passed in are
+// marked with RangeNA. The maps passed in are not modified.
+// In case of failure, function returns an error.
+func PostSolveRange(rhsRange PsRangeMap, objRange PsRangeMap, psRslt *PsSoln) error {
+	var merged map[string]bool    // rows and columns retained in place of a duplicate
+	var cost   map[string]float64 // original objective coefficients of the columns
+	var shift  float64            // change of RHS or objective coefficient by presolve
+
+	if psRslt == nil {
+		return errors.New("PostSolveRange received nil solution")
+	}
+
+	merged = make(map[string]bool)
+	for i := 0; i < len(psOpList); i++ {
+		if psOpList[i].OpType == psopDupRow || psOpList[i].OpType == psopDupCol {
+			merged[psOpList[i].Ref] = true
+		}
+	}
+
+	psRslt.RhsRange = make(PsRangeMap)
+	psRslt.ObjRange = make(PsRangeMap)
+
+	for i := 0; i < len(psOrigRows); i++ {
+		row := psOrigRows[i]
+		if row.Name == psOrigObj.Name || row.Type == "N" {
+			continue
+		}
+
+		item, ok := rhsRange[row.Name]
+		value    := rangeRhs(row, item.Bound)
+
+		if !ok || item.Status != RangeOk || merged[row.Name] || math.Abs(value) >= Plinfy {
+			psRslt.RhsRange[row.Name] = PsRange{Status: RangeNA, Value: row.Rhs}
+			continue
+		}
+
+		shift = value - item.Value
+		psRslt.RhsRange[row.Name] = PsRange{Status: RangeOk, Bound: item.Bound,
+			Value: value, Lo: rangeShift(item.Lo, shift), Up: rangeShift(item.Up, shift)}
+	} // End for all original rows
+
+	cost = make(map[string]float64)
+	for k := 0; k < len(psOrigObj.Coef); k++ {
+		cost[psOrigObj.Coef[k].Name] += psOrigObj.Coef[k].Value
+	}
+
+	for name := range psRslt.VarMap {
+		item, ok := objRange[name]
+
+		if !ok || item.Status != RangeOk || merged[name] {
+			psRslt.ObjRange[name] = PsRange{Status: RangeNA, Value: cost[name]}
+			continue
+		}
+
+		shift = cost[name] - item.Value
+		psRslt.ObjRange[name] = PsRange{Status: RangeOk, Value: cost[name],
+			Lo: rangeShift(item.Lo, shift), Up: rangeShift(item.Up, shift)}
+	} // End for all original columns
+
+	return nil
+}
+
+//==============================================================================
+
+// printRangeMap prints the ranges in the map passed to the function (rngMap),
+// sorted by name, under the title specified (title).
+func printRangeMap(title string, rngMap PsRangeMap) {
+	var names []string  // sorted names of rows or columns
+
+	for name := range rngMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Printf("\n%s\n\n", title)
+	fmt.Printf("%-16s %-3s %-5s %14s %14s %14s\n", "Name", "St", "Bound", "Low",
+		"Current", "High")
+
+	for i := 0; i < len(names); i++ {
+		item := rngMap[names[i]]
+		if item.Status != RangeOk {
+			fmt.Printf("%-16s %-3s %-5s %14s %14e %14s\n", names[i], item.Status, "",
+				"NA", item.Value, "NA")
+			continue
+		}
+
+		fmt.Printf("%-16s %-3s %-5s %14e %14e %14e\n", names[i], item.Status, item.Bound,
+			item.Lo, item.Value, item.Up)
+	}
+}
+
+//==============================================================================
+
+// PrintRanging prints the RHS ranges of the rows and the objective ranges of the
+// columns in the solution passed to the function (psRslt). Ranges which are not
+// available are listed as NA.
+// In case of failure, function returns an error.
+func PrintRanging(psRslt PsSoln) error {
+
+	printRangeMap("RHS RANGING", psRslt.RhsRange)
+	printRangeMap("OBJECTIVE RANGING", psRslt.ObjRange)
+	fmt.Printf("\n")
+
+	return nil
+}
+
+//============================ END OF FILE =====================================
@@ -0,0 +1,905 @@
+//==============================================================================
+// simplex: in-process SIMPLEX solver for small models
+// 01   Oct. 18, 2026   Initial version
+
+
+// This file contains a dense, bounded-variable primal simplex solver which works
+// directly on the model in the Rows, Cols, and Elems global variables. It is
+// intended for small models, and for algorithms which need to solve many small
+// LPs (e.g. finding an irreducible infeasible subsystem) without an external
+// solver. Integer restrictions are ignored, i.e. the LP relaxation is solved.
+//
+// Each row i is given a logical variable s(i) = a(i)x bounded by the RHS bounds
+// of the row, so that the model becomes Ax - s = 0 with bounds on all variables.
+// Phase 1 minimizes the sum of the bound violations of the basic variables, and
+// phase 2 minimizes the objective function. The tableau is recalculated from the
+// model periodically and before each phase ends, to remove rounding errors.
+
+package lpo
+
+import (
+	"github.com/pkg/errors"
+	"math"
+)
+
+
+// SpxSoln contains the solution of the model returned by SimplexSolve. The values
+// of the rows and columns are listed in the same order as Rows and Cols. Row duals
+// and reduced costs follow the convention d = c - yA for a minimization, so the
+// dual of a row is positive if its lower bound is active. If the model is unbounded,
+// Ray contains a direction along which the objective decreases without limit.
+//
+// If the solution is optimal, the ranges give the interval of the active RHS of
+// each row, and of the objective coefficient of each column, within which the
+// basis stays optimal, with infinite ends set to -Plinfy or Plinfy. The active RHS
+// of a row is the bound at which its logical is nonbasic or, if the row is basic,
+// its upper bound if finite and its lower bound otherwise.
+type SpxSoln struct {
+	Status     string      // SpxOptimal, SpxInfeasible, SpxUnbounded, or SpxIterLimit
+	ObjVal     float64     // Value of the objective function
+	Iter       int         // Number of iterations performed
+	ColValue   []float64   // Values of the columns
+	RowAct     []float64   // Activities (LHS) of the rows
+	RowDual    []float64   // Dual values of the rows
+	RedCost    []float64   // Reduced costs of the columns
+	ColStat    []string    // Basis status of the columns
+	RowStat    []string    // Basis status of the rows (i.e. of their logicals)
+	Ray        []float64   // Direction of unboundedness of the columns, or nil
+	RhsRangeLo []float64   // Lowest active RHS of the rows keeping the basis optimal, or nil
+	RhsRangeUp []float64   // Highest active RHS of the rows keeping the basis optimal, or nil
+	ObjRangeLo []float64   // Lowest cost of the columns keeping the basis optimal, or nil
+	ObjRangeUp []float64   // Highest cost of the columns keeping the basis optimal, or nil
+}
+
+// Solution status returned by SimplexSolve
+const (
+	SpxOptimal    = "Optimal"     // Optimal solution found
+	SpxInfeasible = "Infeasible"  // Model has no feasible solution
+	SpxUnbounded  = "Unbounded"   // Objective function is unbounded below
+	SpxIterLimit  = "IterLimit"   // Iteration limit was reached
+)
+
+// Basis status of a column or row returned by SimplexSolve
+const (
+	SpxBasic   = "B"   // Variable is basic
+	SpxAtLower = "L"   // Variable is nonbasic at its lower bound
+	SpxAtUpper = "U"   // Variable is nonbasic at its upper bound
+	SpxAtZero  = "Z"   // Free variable is nonbasic at zero
+)
+
+// Tolerances used by the simplex solver
+const (
+	spxPivTol    = 1.0e-7   // Smallest absolute pivot element accepted
+	spxPrimalTol = 1.0e-9   // Tolerance for violation of bounds during iterations
+	spxDualTol   = 1.0e-9   // Tolerance for reduced costs to be considered improving
+	spxBlandIter = 50       // Degenerate iterations before switching to Bland's rule
+	spxRefactor  = 100      // Iterations after which the tableau is recalculated
+)
+
+// Largest number of tableau entries (rows times rows plus columns) of a model which
+// other functions solve with the simplex solver when no other solver is available
+const spxMaxDense = 1000000
+
+// spxModel is the internal representation of the model used by the simplex solver.
+// Variables 0 to n-1 are the columns, and n to n+m-1 are the logicals of the rows.
+// The tableau expresses the basic variables in terms of the nonbasic ones as
+// x(head[i]) = -sum(tab[i][k] * x(k)) over all nonbasic variables k.
+type spxModel struct {
+	m, n      int          // Number of rows and columns
+	tab     [][]float64    // Tableau, one row per row of the model
+	orig    [][]float64    // Tableau with the logicals basic, used to recalculate tab
+	head      []int        // Variable which is basic in each row of the tableau
+	basicRow  []int        // Row of tableau in which variable is basic, or -1
+	lo, up   []float64     // Lower and upper bounds of all variables
+	x        []float64     // Values of all variables
+	cost     []float64     // Objective function coefficients of all variables
+	iter      int          // Number of iterations performed
+}
+
+//==============================================================================
+
+// spxLoad returns the model in the Rows, Cols, and Elems global variables in the
+// internal format of the simplex solver, with the logicals of the rows basic and
+// the columns nonbasic at one of their bounds, or at zero if they are free.
+// In case of failure, function returns an error.
+func spxLoad(spx *spxModel) error {
+	var nt      int  // total number of variables
+	var index   int  // index of element being processed
+
+	spx.m = len(Rows)
+	spx.n = len(Cols)
+	nt    = spx.m + spx.n
+
+	spx.tab      = make([][]float64, spx.m)
+	spx.head     = make([]int, spx.m)
+	spx.basicRow = make([]int, nt)
+	spx.lo       = make([]float64, nt)
+	spx.up       = make([]float64, nt)
+	spx.x        = make([]float64, nt)
+	spx.cost     = make([]float64, nt)
+	spx.iter     = 0
+
+	for j := 0; j < spx.n; j++ {
+		spx.lo[j] = spxBound(Cols[j].BndLo)
+		spx.up[j] = spxBound(Cols[j].BndUp)
+
+		switch {
+		case !math.IsInf(spx.lo[j], 0):
+			spx.x[j] = spx.lo[j]
+		case !math.IsInf(spx.up[j], 0):
+			spx.x[j] = spx.up[j]
+		default:
+			spx.x[j] = 0
+		}
+		spx.basicRow[j] = -1
+	} // End for all columns
+
+	for i := 0; i < spx.m; i++ {
+		spx.tab[i] = make([]float64, nt)
+		spx.tab[i][spx.n + i] = 1
+		spx.head[i] = spx.n + i
+		spx.basicRow[spx.n + i] = i
+
+		if Rows[i].Type == "N" {
+			spx.lo[spx.n + i] = math.Inf(-1)
+			spx.up[spx.n + i] = math.Inf(1)
+		} else {
+			spx.lo[spx.n + i] = spxBound(Rows[i].RHSlo)
+			spx.up[spx.n + i] = spxBound(Rows[i].RHSup)
+		}
+
+		for k := 0; k < len(Rows[i].HasElems); k++ {
+			index = Rows[i].HasElems[k]
+			spx.tab[i][Elems[index].InCol] -= Elems[index].Value
+			if i == ObjRow {
+				spx.cost[Elems[index].InCol] += Elems[index].Value
+			}
+		}
+	} // End for all rows
+
+	spx.orig = make([][]float64, spx.m)
+	for i := 0; i < spx.m; i++ {
+		spx.orig[i] = append([]float64(nil), spx.tab[i]...)
+	}
+
+	spx.calcBasic()
+
+	return nil
+}
+
+//==============================================================================
+
+// spxFits returns true if the tableau of the model in the Rows, Cols, and Elems
+// global variables, which has a row for each row and a column for each row and
+// column of the model, has at most spxMaxDense entries.
+func spxFits() bool {
+
+	return spxFitsSize(len(Rows), len(Cols))
+}
+
+//==============================================================================
+
+// spxFitsSize returns true if the tableau of a model with the number of rows and
+// columns specified (numRows, numCols) has at most spxMaxDense entries.
+func spxFitsSize(numRows int, numCols int) bool {
+
+	return numRows * (numRows + numCols) <= spxMaxDense
+}
+
+//==============================================================================
+
+// spxBound converts a bound of the model to the value used by the simplex solver,
+// in which bounds at or beyond Plinfy are infinite.
+func spxBound(bound float64) float64 {
+
+	if bound >= Plinfy {
+		return math.Inf(1)
+	}
+	if bound <= -Plinfy {
+		return math.Inf(-1)
+	}
+
+	return bound
+}
+
+//==============================================================================
+
+// boundsOk returns false if the lower bound of any variable exceeds its upper bound
+// by more than the feasibility tolerance, in which case the model is infeasible.
+func (spx *spxModel) boundsOk() bool {
+
+	for k := 0; k < len(spx.lo); k++ {
+		if spx.lo[k] > spx.up[k] + Featol {
+			return false
+		}
+	}
+
+	return true
+}
+
+//==============================================================================
+
+// calcBasic calculates the values of the basic variables from the values of the
+// nonbasic variables.
+func (spx *spxModel) calcBasic() {
+
+	for i := 0; i < spx.m; i++ {
+		value := 0.0
+		for k := 0; k < len(spx.x); k++ {
+			if spx.basicRow[k] < 0 && spx.tab[i][k] != 0 {
+				value -= spx.tab[i][k] * spx.x[k]
+			}
+		}
+		spx.x[spx.head[i]] = value
+	}
+}
+
+//==============================================================================
+
+// violation returns the amount by which the variable specified by k violates its
+// bounds, negative if it is below its lower bound and positive if above its upper.
+func (spx *spxModel) violation(k int) float64 {
+
+	if spx.x[k] < spx.lo[k] - spxPrimalTol {
+		return spx.x[k] - spx.lo[k]
+	}
+	if spx.x[k] > spx.up[k] + spxPrimalTol {
+		return spx.x[k] - spx.up[k]
+	}
+
+	return 0
+}
+
+//==============================================================================
+
+// redCosts calculates the reduced costs of all variables for the cost vector
+// passed in (cost) and returns them in d.
+func (spx *spxModel) redCosts(cost []float64, d []float64) {
+
+	copy(d, cost)
+
+	for i := 0; i < spx.m; i++ {
+		cb := cost[spx.head[i]]
+		if cb == 0 {
+			continue
+		}
+		for k := 0; k < len(d); k++ {
+			d[k] -= cb * spx.tab[i][k]
+		}
+	}
+
+	for i := 0; i < spx.m; i++ {
+		d[spx.head[i]] = 0
+	}
+}
+
+//==============================================================================
+
+// pivot makes the variable specified by q basic in the row of the tableau r.
+func (spx *spxModel) pivot(r int, q int) {
+
+	spxPivotTab(spx.tab, r, q)
+
+	spx.basicRow[spx.head[r]] = -1
+	spx.head[r]     = q
+	spx.basicRow[q] = r
+}
+
+//==============================================================================
+
+// spxPivotTab divides row r of the tableau passed in (tab) by its element in column
+// q, and eliminates column q from all other rows.
+func spxPivotTab(tab [][]float64, r int, q int) {
+
+	pivRow := tab[r]
+	pivVal := pivRow[q]
+
+	for k := 0; k < len(pivRow); k++ {
+		pivRow[k] /= pivVal
+	}
+
+	for i := 0; i < len(tab); i++ {
+		if i == r || tab[i][q] == 0 {
+			continue
+		}
+		factor := tab[i][q]
+		for k := 0; k < len(pivRow); k++ {
+			if pivRow[k] != 0 {
+				tab[i][k] -= factor * pivRow[k]
+			}
+		}
+		tab[i][q] = 0
+	}
+}
+
+//==============================================================================
+
+// refactor recalculates the tableau of the current basis from the tableau with the
+// logicals basic, which removes the rounding errors accumulated by the pivots, and
+// then the values of the basic variables. The pivot of each basic variable is the
+// largest element of its column in the rows not yet used. If the basis is nearly
+// singular, the tableau is left unchanged.
+func (spx *spxModel) refactor() {
+	var tab [][]float64  // tableau being calculated
+	var head      []int  // variable which is basic in each row of tab, or -1
+
+	tab  = make([][]float64, spx.m)
+	head = make([]int, spx.m)
+	for i := 0; i < spx.m; i++ {
+		tab[i]  = append([]float64(nil), spx.orig[i]...)
+		head[i] = -1
+	}
+
+	for j := 0; j < spx.m; j++ {
+		q    := spx.head[j]
+		r    := -1
+		best := spxPivTol
+		for i := 0; i < spx.m; i++ {
+			if head[i] < 0 && math.Abs(tab[i][q]) > best {
+				r, best = i, math.Abs(tab[i][q])
+			}
+		}
+		if r < 0 {
+			log(pDEB, "Simplex unable to recalculate tableau of singular basis.\n")
+			spx.calcBasic()
+			return
+		}
+		spxPivotTab(tab, r, q)
+		head[r] = q
+	} // End for all basic variables
+
+	spx.tab  = tab
+	spx.head = head
+	for i := 0; i < spx.m; i++ {
+		spx.basicRow[head[i]] = i
+	}
+
+	spx.calcBasic()
+}
+
+//==============================================================================
+
+// iterate performs simplex iterations until no improving variable can enter the
+// basis. In phase 1, the cost of each basic variable is set to -1 or +1 if it is
+// below or above its bounds, while in phase 2 the objective function is used. The
+// function returns the status of the model, and the direction of unboundedness
+// (ray) of all variables if the objective decreases without limit.
+// In case of failure, function returns an error.
+func (spx *spxModel) iterate(phase int, maxIter int, status *string, ray *[]float64) error {
+	var cost  []float64  // costs used in this phase
+	var d     []float64  // reduced costs
+	var q          int   // variable entering the basis
+	var r          int   // row of the tableau leaving the basis, -1 for a bound flip
+	var dir    float64   // direction in which entering variable moves
+	var theta  float64   // step length
+	var degen      int   // number of consecutive degenerate iterations
+	var fresh     bool   // true if the tableau was recalculated since the last pivot
+
+	nt   := spx.m + spx.n
+	cost  = make([]float64, nt)
+	d     = make([]float64, nt)
+
+	for {
+		if phase == 1 {
+			infeas := false
+			for k := 0; k < nt; k++ {
+				cost[k] = 0
+			}
+			for i := 0; i < spx.m; i++ {
+				viol := spx.violation(spx.head[i])
+				if viol < 0 {
+					cost[spx.head[i]] = -1
+					infeas = true
+				} else if viol > 0 {
+					cost[spx.head[i]] = 1
+					infeas = true
+				}
+			}
+			if !infeas {
+				*status = SpxOptimal
+				return nil
+			}
+		} else {
+			copy(cost, spx.cost)
+		}
+
+		spx.redCosts(cost, d)
+
+		// Choose the entering variable, by largest reduced cost or by lowest
+		// index (Bland's rule) if the iterations are degenerate.
+		q   = -1
+		dir = 0
+		best := spxDualTol
+		for k := 0; k < nt; k++ {
+			if spx.basicRow[k] >= 0 || spx.lo[k] == spx.up[k] {
+				continue
+			}
+			if d[k] < -spxDualTol && spx.x[k] < spx.up[k] && -d[k] > best {
+				q, dir, best = k, 1, -d[k]
+			} else if d[k] > spxDualTol && spx.x[k] > spx.lo[k] && d[k] > best {
+				q, dir, best = k, -1, d[k]
+			}
+			if q >= 0 && degen > spxBlandIter {
+				break
+			}
+		} // End for all nonbasic variables
+
+		// The tableau is recalculated before the phase ends, so that rounding
+		// errors accumulated by the pivots cannot end it early.
+		if q < 0 && !fresh {
+			spx.refactor()
+			fresh = true
+			continue
+		}
+
+		if q < 0 {
+			if phase == 1 {
+				*status = SpxInfeasible
+			} else {
+				*status = SpxOptimal
+			}
+			return nil
+		}
+
+		if spx.iter >= maxIter {
+			*status = SpxIterLimit
+			return nil
+		}
+		spx.iter++
+
+		// Ratio test: find the first variable to reach a bound.
+		r     = -1
+		theta = math.Inf(1)
+		if !math.IsInf(spx.lo[q], 0) && !math.IsInf(spx.up[q], 0) {
+			theta = spx.up[q] - spx.lo[q]
+		}
+
+		// Ties are broken by the largest pivot element, or by the lowest index of
+		// the basic variable if Bland's rule is used, which prevents cycling.
+		bland   := degen > spxBlandIter
+		bestPiv := 0.0
+		for i := 0; i < spx.m; i++ {
+			alpha := -spx.tab[i][q] * dir
+			if math.Abs(alpha) < spxPivTol {
+				continue
+			}
+
+			k     := spx.head[i]
+			limit := math.Inf(1)
+			viol  := 0.0
+			if phase == 1 {
+				viol = spx.violation(k)
+			}
+
+			switch {
+			case viol < 0 && alpha > 0:
+				limit = (spx.lo[k] - spx.x[k]) / alpha
+			case viol > 0 && alpha < 0:
+				limit = (spx.up[k] - spx.x[k]) / alpha
+			case viol == 0 && alpha > 0 && !math.IsInf(spx.up[k], 0):
+				limit = (spx.up[k] - spx.x[k]) / alpha
+			case viol == 0 && alpha < 0 && !math.IsInf(spx.lo[k], 0):
+				limit = (spx.lo[k] - spx.x[k]) / alpha
+			}
+
+			if limit < 0 {
+				limit = 0
+			}
+
+			tie := limit <= theta + spxPrimalTol && r >= 0
+			if limit < theta - spxPrimalTol ||
+				(tie && !bland && math.Abs(alpha) > bestPiv) ||
+				(tie && bland && k < spx.head[r]) {
+				theta   = limit
+				r       = i
+				bestPiv = math.Abs(alpha)
+			}
+		} // End for all rows of tableau
+
+		if math.IsInf(theta, 1) {
+			if phase == 1 {
+				return errors.New("simplex found unbounded step in phase 1")
+			}
+			*ray = make([]float64, nt)
+			(*ray)[q] = dir
+			for i := 0; i < spx.m; i++ {
+				(*ray)[spx.head[i]] = -spx.tab[i][q] * dir
+			}
+			*status = SpxUnbounded
+			return nil
+		}
+
+		if theta <= spxPrimalTol {
+			degen++
+		} else {
+			degen = 0
+		}
+
+		// Move the entering variable and update the basic variables.
+		spx.x[q] += dir * theta
+		for i := 0; i < spx.m; i++ {
+			spx.x[spx.head[i]] -= spx.tab[i][q] * dir * theta
+		}
+
+		if r < 0 {
+			// Bound flip, the entering variable stays nonbasic at its other bound.
+			if dir > 0 {
+				spx.x[q] = spx.up[q]
+			} else {
+				spx.x[q] = spx.lo[q]
+			}
+			continue
+		}
+
+		// The leaving variable becomes nonbasic at the bound it reached.
+		k     := spx.head[r]
+		alpha := -spx.tab[r][q] * dir
+		if alpha > 0 {
+			if phase == 1 && spx.x[k] < spx.lo[k] + spxPrimalTol && !math.IsInf(spx.lo[k], 0) &&
+				math.Abs(spx.x[k] - spx.lo[k]) < math.Abs(spx.x[k] - spx.up[k]) {
+				spx.x[k] = spx.lo[k]
+			} else {
+				spx.x[k] = spx.up[k]
+			}
+		} else {
+			if phase == 1 && spx.x[k] > spx.up[k] - spxPrimalTol && !math.IsInf(spx.up[k], 0) &&
+				math.Abs(spx.x[k] - spx.up[k]) < math.Abs(spx.x[k] - spx.lo[k]) {
+				spx.x[k] = spx.up[k]
+			} else {
+				spx.x[k] = spx.lo[k]
+			}
+		}
+
+		spx.pivot(r, q)
+		fresh = false
+		if spx.iter % spxRefactor == 0 {
+			spx.refactor()
+			fresh = true
+		} else {
+			spx.calcBasic()
+		}
+	} // End for all iterations
+}
+
+//==============================================================================
+
+// maxIter returns the maximum number of iterations of each phase of the simplex
+// method, which grows with the size of the model.
+func (spx *spxModel) maxIter() int {
+
+	return 100 * (spx.m + spx.n + 10)
+}
+
+//==============================================================================
+
+// nearFeasible returns true if no variable violates its bounds by more than the
+// feasibility tolerance, since phase 1 may end with such violations and report
+// the model infeasible.
+func (spx *spxModel) nearFeasible() bool {
+	var maxViol float64  // largest violation of a bound
+
+	for k := 0; k < spx.m + spx.n; k++ {
+		maxViol = math.Max(maxViol, math.Abs(spx.violation(k)))
+	}
+
+	return maxViol <= Featol
+}
+
+//==============================================================================
+
+// solve solves the model with the simplex method and returns the solution in soln.
+// In case of failure, function returns an error.
+func (spx *spxModel) solve(soln *SpxSoln) error {
+	var ray []float64  // direction of unboundedness
+	var d   []float64  // reduced costs of all variables
+	var err     error  // error returned by secondary functions called
+
+	*soln = SpxSoln{}
+
+	if !spx.boundsOk() {
+		soln.Status = SpxInfeasible
+	} else if err = spx.iterate(1, spx.maxIter(), &soln.Status, &ray); err != nil {
+		return errors.Wrap(err, "simplex failed")
+	}
+
+	if soln.Status == SpxInfeasible && spx.nearFeasible() {
+		soln.Status = SpxOptimal
+	}
+
+	if soln.Status == SpxOptimal {
+		if err = spx.iterate(2, spx.maxIter(), &soln.Status, &ray); err != nil {
+			return errors.Wrap(err, "simplex failed")
+		}
+	}
+
+	soln.Iter     = spx.iter
+	soln.ColValue = make([]float64, spx.n)
+	soln.RedCost  = make([]float64, spx.n)
+	soln.ColStat  = make([]string, spx.n)
+	soln.RowAct   = make([]float64, spx.m)
+	soln.RowDual  = make([]float64, spx.m)
+	soln.RowStat  = make([]string, spx.m)
+
+	d = make([]float64, spx.m + spx.n)
+	spx.redCosts(spx.cost, d)
+
+	soln.ObjVal = -objRowConst
+	for j := 0; j < spx.n; j++ {
+		soln.ColValue[j] = spx.x[j]
+		soln.RedCost[j]  = d[j]
+		soln.ColStat[j]  = spx.status(j)
+		soln.ObjVal     += spx.cost[j] * spx.x[j]
+	}
+
+	for i := 0; i < spx.m; i++ {
+		soln.RowAct[i]  = spx.x[spx.n + i]
+		soln.RowDual[i] = d[spx.n + i]
+		soln.RowStat[i] = spx.status(spx.n + i)
+	}
+
+	if ray != nil {
+		soln.Ray = ray[:spx.n]
+	}
+
+	if soln.Status == SpxOptimal {
+		spx.ranging(d, soln)
+	}
+
+	return nil
+}
+
+//==============================================================================
+
+// ranging calculates the ranges of the active RHS of the rows and of the objective
+// coefficients of the columns within which the current basis stays optimal, from
+// the reduced costs of all variables (d), and returns them in soln. Changing the
+// active RHS of a nonbasic row moves the basic variables along the column of its
+// logical in the tableau, and the range ends where the first one reaches a bound.
+// Changing the cost of a basic column changes the reduced costs of the nonbasic
+// variables by its row of the tableau, and the range ends where the first one
+// changes sign.
+func (spx *spxModel) ranging(d []float64, soln *SpxSoln) {
+	var lo, up  float64  // ends of range
+	var act     float64  // active RHS or cost of row or column being processed
+	var k           int  // index of logical of row
+
+	soln.RhsRangeLo = make([]float64, spx.m)
+	soln.RhsRangeUp = make([]float64, spx.m)
+	soln.ObjRangeLo = make([]float64, spx.n)
+	soln.ObjRangeUp = make([]float64, spx.n)
+
+	for i := 0; i < spx.m; i++ {
+		k   = spx.n + i
+		act = spx.x[k]
+
+		switch {
+		case math.IsInf(spx.lo[k], -1) && math.IsInf(spx.up[k], 1):
+			lo, up = math.Inf(-1), math.Inf(1)
+
+		case spx.basicRow[k] >= 0 && spx.lo[k] == spx.up[k]:
+			lo, up = act, act
+
+		case spx.basicRow[k] >= 0 && !math.IsInf(spx.up[k], 1):
+			lo, up = act, math.Inf(1)
+
+		case spx.basicRow[k] >= 0:
+			lo, up = math.Inf(-1), act
+
+		default:
+			spx.rhsStep(k, &lo, &up)
+			lo += act
+			up += act
+
+			// The bound of a ranged row cannot move past its other bound.
+			if spx.lo[k] != spx.up[k] {
+				if act == spx.lo[k] {
+					up = math.Min(up, spx.up[k])
+				} else {
+					lo = math.Max(lo, spx.lo[k])
+				}
+			}
+		} // End switch on basis status of row
+
+		soln.RhsRangeLo[i] = spxRangeEnd(lo)
+		soln.RhsRangeUp[i] = spxRangeEnd(up)
+	} // End for all rows
+
+	for j := 0; j < spx.n; j++ {
+		act = spx.cost[j]
+
+		switch {
+		case spx.basicRow[j] >= 0:
+			spx.costStep(spx.basicRow[j], d, &lo, &up)
+			lo += act
+			up += act
+
+		case spx.lo[j] == spx.up[j]:
+			lo, up = math.Inf(-1), math.Inf(1)
+
+		case spx.x[j] == spx.lo[j]:
+			lo, up = math.Min(act - d[j], act), math.Inf(1)
+
+		case spx.x[j] == spx.up[j]:
+			lo, up = math.Inf(-1), math.Max(act - d[j], act)
+
+		default:
+			lo, up = act, act
+		} // End switch on basis status of column
+
+		soln.ObjRangeLo[j] = spxRangeEnd(lo)
+		soln.ObjRangeUp[j] = spxRangeEnd(up)
+	} // End for all columns
+}
+
+//==============================================================================
+
+// rhsStep returns the smallest (lo) and largest (up) change of the value of the
+// nonbasic variable specified by k for which all basic variables stay within
+// their bounds.
+func (spx *spxModel) rhsStep(k int, lo *float64, up *float64) {
+
+	*lo = math.Inf(-1)
+	*up = math.Inf(1)
+
+	for r := 0; r < spx.m; r++ {
+		alpha := -spx.tab[r][k]
+		if math.Abs(alpha) < spxPivTol {
+			continue
+		}
+
+		b := spx.head[r]
+		if alpha > 0 {
+			*up = math.Min(*up, (spx.up[b] - spx.x[b]) / alpha)
+			*lo = math.Max(*lo, (spx.lo[b] - spx.x[b]) / alpha)
+		} else {
+			*up = math.Min(*up, (spx.lo[b] - spx.x[b]) / alpha)
+			*lo = math.Max(*lo, (spx.up[b] - spx.x[b]) / alpha)
+		}
+	} // End for all rows of tableau
+
+	*lo = math.Min(*lo, 0)
+	*up = math.Max(*up, 0)
+}
+
+//==============================================================================
+
+// costStep returns the smallest (lo) and largest (up) change of the cost of the
+// variable basic in row r of the tableau for which the reduced costs (d) of all
+// nonbasic variables keep the sign required for optimality.
+func (spx *spxModel) costStep(r int, d []float64, lo *float64, up *float64) {
+
+	*lo = math.Inf(-1)
+	*up = math.Inf(1)
+
+	for k := 0; k < spx.m + spx.n; k++ {
+		alpha := spx.tab[r][k]
+		if spx.basicRow[k] >= 0 || spx.lo[k] == spx.up[k] || math.Abs(alpha) < spxPivTol {
+			continue
+		}
+
+		// The reduced cost of k changes to d[k] - alpha * step.
+		ratio := d[k] / alpha
+		switch {
+		case spx.x[k] == spx.lo[k]:
+			if alpha > 0 {
+				*up = math.Min(*up, ratio)
+			} else {
+				*lo = math.Max(*lo, ratio)
+			}
+
+		case spx.x[k] == spx.up[k]:
+			if alpha > 0 {
+				*lo = math.Max(*lo, ratio)
+			} else {
+				*up = math.Min(*up, ratio)
+			}
+
+		default:
+			*lo, *up = 0, 0
+		}
+	} // End for all variables
+
+	*lo = math.Min(*lo, 0)
+	*up = math.Max(*up, 0)
+}
+
+//==============================================================================
+
+// spxRangeEnd converts the end of a range calculated by the simplex solver to the
+// value returned to the caller, in which infinite ends are set to -Plinfy or Plinfy.
+func spxRangeEnd(value float64) float64 {
+
+	if value >= Plinfy {
+		return Plinfy
+	}
+	if value <= -Plinfy {
+		return -Plinfy
+	}
+
+	return value
+}
+
+//==============================================================================
+
+// status returns the basis status of the variable specified by k.
+func (spx *spxModel) status(k int) string {
+
+	switch {
+	case spx.basicRow[k] >= 0:
+		return SpxBasic
+	case spx.x[k] == spx.lo[k]:
+		return SpxAtLower
+	case spx.x[k] == spx.up[k]:
+		return SpxAtUpper
+	}
+
+	return SpxAtZero
+}
+
+//==============================================================================
+
+// SimplexSolve solves the LP relaxation of the model in the Rows, Cols, and Elems
+// global variables with the in-process simplex solver, and returns the solution in
+// soln. The objective function is minimized. The solver uses a dense tableau and
+// is only suitable for small models.
+// In case of failure, the function returns an error.
+func SimplexSolve(soln *SpxSoln) error {
+	var spx spxModel  // model in the format used by the solver
+	var err    error  // error returned by secondary functions called
+
+	if err = spxLoad(&spx); err != nil {
+		return errors.Wrap(err, "SimplexSolve failed")
+	}
+
+	if err = spx.solve(soln); err != nil {
+		return errors.Wrap(err, "SimplexSolve failed")
+	}
+
+	log(pDEB, "Simplex status %s after %d iterations, objective %f.\n",
+		soln.Status, soln.Iter, soln.ObjVal)
+
+	return nil
+}
+
+//==============================================================================
+
+// SimplexFeasible sets feasible to true if the LP relaxation of the model in the
+// Rows, Cols, and Elems global variables has a feasible solution, as found by the
+// in-process simplex solver. It can be passed to FindIIS as the solver function.
+// In case of failure, the function returns an error.
+func SimplexFeasible(feasible *bool) error {
+	var spx  spxModel   // model loaded into the simplex solver
+	var ray []float64   // direction of unboundedness, not used
+	var status string   // status at the end of phase 1
+	var err     error   // error returned by secondary functions called
+
+	*feasible = false
+
+	// The objective function is not needed, so only phase 1 is performed.
+	if err = spxLoad(&spx); err != nil {
+		return errors.Wrap(err, "SimplexFeasible failed")
+	}
+
+	if !spx.boundsOk() {
+		return nil
+	}
+
+	if err = spx.iterate(1, spx.maxIter(), &status, &ray); err != nil {
+		return errors.Wrap(err, "SimplexFeasible failed")
+	}
+
+	switch status {
+	case SpxOptimal:
+		*feasible = true
+
+	case SpxInfeasible:
+		*feasible = spx.nearFeasible()
+
+	default:
+		return errors.Errorf("SimplexFeasible stopped with status %s", status)
+	}
+
+	return nil
+}
+
+//============================ END OF FILE =====================================
@@ -0,0 +1,66 @@
+//==============================================================================
+// simplex_test: TESTS of the in-process simplex solver
+// 01   Oct. 18, 2026   Initial version
+
+
+// The tests solve the sample LP models supplied with lporun, AFIRO and BORE3D,
+// with the in-process simplex solver and check the status, the objective function
+// value, and the feasibility of the solution. BORE3D is highly degenerate and
+// cycles unless the ratio test also follows Bland's rule.
+
+package lpo
+
+import (
+	"math"
+	"testing"
+)
+
+//==============================================================================
+
+// solveSampleLp reads the model in the file passed in (fileName), solves it with
+// SimplexSolve, and checks that the solution is optimal with the objective function
+// value expected (objVal) and satisfies the rows and bounds of the model.
+func solveSampleLp(t *testing.T, fileName string, objVal float64) {
+	var soln SpxSoln  // solution returned by the solver
+
+	quietLog(t)
+	loadModel(t, fileName)
+
+	if err := SimplexSolve(&soln); err != nil {
+		t.Fatalf("SimplexSolve(%s): %v", fileName, err)
+	}
+
+	if soln.Status != SpxOptimal {
+		t.Fatalf("%s: status %s after %d iterations, want %s", fileName, soln.Status,
+			soln.Iter, SpxOptimal)
+	}
+
+	if math.Abs(soln.ObjVal - objVal) > 1.0e-6 * math.Max(1, math.Abs(objVal)) {
+		t.Errorf("%s: objective %f, want %f", fileName, soln.ObjVal, objVal)
+	}
+
+	checkPoint(t, fileName, soln.ColValue)
+}
+
+//==============================================================================
+
+// TestSimplexAfiro solves the small sample LP model (AFIRO).
+func TestSimplexAfiro(t *testing.T) {
+
+	solveSampleLp(t, "lporun/inputSmallLp.txt", -464.753143)
+}
+
+//==============================================================================
+
+// TestSimplexBore3d solves the large sample LP model (BORE3D), which cycled when
+// ties in the ratio test were broken by the largest pivot under Bland's rule.
+func TestSimplexBore3d(t *testing.T) {
+
+	if testing.Short() {
+		t.Skip("skipping BORE3D in short mode")
+	}
+
+	solveSampleLp(t, "lporun/inputLargeLP.txt", 1373.080394)
+}
+
+//============================ END OF FILE =====================================
@@ -0,0 +1,355 @@
+//==============================================================================
+// solnio: SOLution Input/Output functions
+// 01   Oct. 18, 2026   Initial version
+
+
+// This file contains functions which read the solutions of reduced models that
+// were solved by external solvers, and complete them to solutions of the original
+// model using the list of presolve operations.
+
+package lpo
+
+import (
+	"bufio"
+	"github.com/pkg/errors"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+
+// Formats of solution files that can be read by ReadSolnFile
+const (
+	SolnFmtSol  = "SOL"   // MIPLIB .sol file of variable name/value pairs
+	SolnFmtCoin = "COIN"  // Solution file written by the CBC or CLP solver
+	SolnFmtCsv  = "CSV"   // CSV file of variable name, value, and reduced cost
+)
+
+
+//==============================================================================
+
+// parseSolnLine reads the next line of a solution file and returns its tokens,
+// split at white space or, if sep is not empty, at the separator (sep). Blank
+// lines and lines starting with "#" are skipped. The line number is updated
+// in lineNum, and io.EOF is returned when the end of the file is reached.
+func parseSolnLine(reader *bufio.Reader, sep string, lineNum *int) ([]string, error) {
+	var token []string  // tokens of the line
+
+	for {
+		*lineNum++
+		curLine, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, errors.Wrapf(err, "Problem reading line %d", *lineNum)
+		}
+
+		trimmed := strings.TrimSpace(curLine)
+		if trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			if sep == "" {
+				token = strings.Fields(trimmed)
+			} else {
+				token = strings.Split(trimmed, sep)
+				for i := 0; i < len(token); i++ {
+					token[i] = strings.TrimSpace(token[i])
+				}
+			}
+			return token, nil
+		}
+
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+	} // End for all lines skipped
+}
+
+//==============================================================================
+
+// readSolFile reads a MIPLIB .sol file, in which each line contains a variable
+// name and its value, and adds the variables to the map (varMap). Lines giving
+// the objective function value ("=obj=" or "objective value:") are skipped, as
+// are any fields following the value.
+// In case of failure, function returns an error.
+func readSolFile(reader *bufio.Reader, varMap PsResVarMap) error {
+	var lineNum int  // number of the line being read
+
+	for {
+		token, err := parseSolnLine(reader, "", &lineNum)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "readSolFile failed")
+		}
+
+		if strings.EqualFold(token[0], "=obj=") || strings.EqualFold(token[0], "objective") {
+			continue
+		}
+
+		if len(token) < 2 {
+			return errors.Errorf("readSolFile found no value in line %d", lineNum)
+		}
+
+		value, err := strconv.ParseFloat(token[1], 64)
+		if err != nil {
+			return errors.Wrapf(err, "readSolFile found invalid value in line %d", lineNum)
+		}
+
+		vMapItem       := varMap[token[0]]
+		vMapItem.Status = psVarStatNA
+		vMapItem.Value  = value
+		varMap[token[0]] = vMapItem
+	} // End for all lines in file
+
+	return nil
+}
+
+//==============================================================================
+
+// readCoinFile reads a solution file written by the CBC or CLP solver and adds
+// the rows and variables to the maps (conMap, varMap). The first line contains
+// the status of the solution, and an error is returned if the model was found
+// to be infeasible or unbounded. Each following line contains an index, a name,
+// a value and a dual value or reduced cost, and may be preceded by "**" if the
+// value violates its bounds. If rows are included, they are listed before the
+// columns, and the indices restart from zero at the first column. The slack of
+// a row is calculated from its activity if the row is in the current model.
+// In case of failure, function returns an error.
+func readCoinFile(reader *bufio.Reader, conMap PsResConMap, varMap PsResVarMap) error {
+	var lineNum     int       // number of the line being read
+	var lastIndex   int       // index of the previous entry
+	var names    []string     // names of the entries read
+	var values   []float64    // values of the entries read
+	var duals    []float64    // duals or reduced costs of the entries read
+	var firstCol    int       // position of the first column in the entries read
+	var numbers  [3]float64   // index, value, and dual of the entry being read
+	var rowRhs      float64   // RHS of row in current model
+	var err         error     // error returned by secondary functions called
+
+	// Check the status of the solution in the first line.
+	token, err := parseSolnLine(reader, "", &lineNum)
+	if err != nil {
+		return errors.Wrap(err, "readCoinFile found no status")
+	}
+
+	status := strings.ToLower(strings.Join(token, " "))
+	if strings.Contains(status, "infeasible") || strings.Contains(status, "unbounded") {
+		return errors.Errorf("readCoinFile found solution status: %s", strings.Join(token, " "))
+	}
+
+	lastIndex = -1
+	firstCol  = 0
+
+	for {
+		token, err = parseSolnLine(reader, "", &lineNum)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "readCoinFile failed")
+		}
+
+		if token[0] == "**" {
+			token = token[1:]
+		}
+
+		if len(token) < 4 {
+			return errors.Errorf("readCoinFile found too few fields in line %d", lineNum)
+		}
+
+		for k, field := range []string{token[0], token[2], token[3]} {
+			if numbers[k], err = strconv.ParseFloat(field, 64); err != nil {
+				return errors.Wrapf(err, "readCoinFile found invalid value in line %d", lineNum)
+			}
+		}
+
+		// If the index restarts, the entries read so far were rows.
+		if int(numbers[0]) <= lastIndex {
+			if firstCol != 0 {
+				return errors.Errorf("readCoinFile found third section in line %d", lineNum)
+			}
+			firstCol = len(names)
+		}
+
+		lastIndex = int(numbers[0])
+		names     = append(names, token[1])
+		values    = append(values, numbers[1])
+		duals     = append(duals, numbers[2])
+	} // End for all lines in file
+
+	// Make the map of rows in the current model.
+	rowIndex := make(map[string]int)
+	for i := 0; i < len(Rows); i++ {
+		rowIndex[Rows[i].Name] = i
+	}
+
+	for i := 0; i < firstCol; i++ {
+		cMapItem       := conMap[names[i]]
+		cMapItem.Status = psConStatNA
+		cMapItem.Pi     = duals[i]
+		cMapItem.Dual   = duals[i]
+
+		if iRow, ok := rowIndex[names[i]]; ok {
+			rowRhs = Rows[iRow].RHSlo
+			if Rows[iRow].Type == "L" {
+				rowRhs = Rows[iRow].RHSup
+			}
+			cMapItem.Type        = Rows[iRow].Type
+			cMapItem.Rhs         = rowRhs
+			cMapItem.ScaleFactor = Rows[iRow].ScaleFactor
+			cMapItem.Slack       = rowRhs - values[i]
+		}
+
+		conMap[names[i]] = cMapItem
+	} // End for all rows
+
+	for i := firstCol; i < len(names); i++ {
+		vMapItem            := varMap[names[i]]
+		vMapItem.Status      = psVarStatNA
+		vMapItem.Value       = values[i]
+		vMapItem.ReducedCost = duals[i]
+		varMap[names[i]]     = vMapItem
+	} // End for all columns
+
+	return nil
+}
+
+//==============================================================================
+
+// readCsvFile reads a CSV file in which each line contains a variable name, its
+// value, and optionally its reduced cost, and adds the variables to the map
+// (varMap). A header line is skipped if its value field is not a number.
+// In case of failure, function returns an error.
+func readCsvFile(reader *bufio.Reader, varMap PsResVarMap) error {
+	var lineNum int      // number of the line being read
+	var numRead int      // number of lines read which were not skipped
+	var redCost float64  // reduced cost of the variable
+
+	for {
+		token, err := parseSolnLine(reader, ",", &lineNum)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "readCsvFile failed")
+		}
+
+		if len(token) < 2 {
+			return errors.Errorf("readCsvFile found no value in line %d", lineNum)
+		}
+
+		numRead++
+		value, err := strconv.ParseFloat(token[1], 64)
+		if err != nil {
+			if numRead == 1 {
+				continue
+			}
+			return errors.Wrapf(err, "readCsvFile found invalid value in line %d", lineNum)
+		}
+
+		redCost = 0
+		if len(token) > 2 && token[2] != "" {
+			if redCost, err = strconv.ParseFloat(token[2], 64); err != nil {
+				return errors.Wrapf(err, "readCsvFile found invalid reduced cost in line %d", lineNum)
+			}
+		}
+
+		vMapItem            := varMap[token[0]]
+		vMapItem.Status      = psVarStatNA
+		vMapItem.Value       = value
+		vMapItem.ReducedCost = redCost
+		varMap[token[0]]     = vMapItem
+	} // End for all lines in file
+
+	return nil
+}
+
+//==============================================================================
+
+// ReadSolnFile reads the solution of a model from a file (fileName) written by
+// an external solver in the format specified (SolnFmtSol, SolnFmtCoin, or
+// SolnFmtCsv), and adds the constraints and variables found in the file to the
+// maps passed to the function (conMap, varMap). Only the Coin-OR format includes
+// constraints. Variables and constraints which are not in the file are not added.
+// In case of failure, the function returns an error.
+func ReadSolnFile(fileName string, format string, conMap PsResConMap, varMap PsResVarMap) error {
+	var err error  // error returned by secondary functions called
+
+	solnFile, err := os.Open(fileName)
+	if err != nil {
+		return errors.Wrapf(err, "ReadSolnFile failed to open file %s", fileName)
+	}
+
+	log(pINFO, "\nReading %s solution file %s.\n", format, fileName)
+	defer solnFile.Close()
+	solnReader := bufio.NewReader(solnFile)
+
+	switch strings.ToUpper(format) {
+
+	case SolnFmtSol:
+		err = readSolFile(solnReader, varMap)
+
+	case SolnFmtCoin:
+		err = readCoinFile(solnReader, conMap, varMap)
+
+	case SolnFmtCsv:
+		err = readCsvFile(solnReader, varMap)
+
+	default:
+		return errors.Errorf("ReadSolnFile received unknown format %s", format)
+
+	} // End switch on format
+
+	if err != nil {
+		return errors.Wrapf(err, "ReadSolnFile failed to read file %s", fileName)
+	}
+
+	return nil
+}
+
+//==============================================================================
+
+// ImportSoln reads the solution of the reduced model from a file (fileName) in
+// the format specified, as described for ReadSolnFile, and completes it to the
+// solution of the original model (psRslt) using PostSolve and the presolve
+// operations recorded by ReduceMatrix or read by ReadPsopFile. Solution files
+// usually omit variables which are zero, so the rows and columns of the current
+// model (i.e. the reduced model) which are not in the file are added with zero
+// values. The objective function value is calculated from the original objective
+// function.
+// In case of failure, the function returns an error.
+func ImportSoln(fileName string, format string, psRslt *PsSoln) error {
+	var curRow psRow  // row of the current model
+	var err    error  // error returned by secondary functions called
+
+	conMap := make(PsResConMap)
+	varMap := make(PsResVarMap)
+
+	if err = ReadSolnFile(fileName, format, conMap, varMap); err != nil {
+		return errors.Wrap(err, "ImportSoln failed")
+	}
+
+	for i := 0; i < len(Rows); i++ {
+		if i == ObjRow {
+			continue
+		}
+		if _, ok := conMap[Rows[i].Name]; !ok {
+			_ = translateRow(Rows[i], &curRow)
+			_ = addConMapItem(conMap, curRow)
+		}
+	} // End for all rows in current model
+
+	for i := 0; i < len(Cols); i++ {
+		vMapItem            := varMap[Cols[i].Name]
+		vMapItem.Status      = psVarStatNA
+		vMapItem.ScaleFactor = Cols[i].ScaleFactor
+		varMap[Cols[i].Name] = vMapItem
+	} // End for all columns in current model
+
+	if err = PostSolve(conMap, varMap, psRslt); err != nil {
+		return errors.Wrap(err, "ImportSoln failed")
+	}
+
+	return nil
+}
+
+//============================ END OF FILE =====================================